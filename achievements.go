@@ -0,0 +1,194 @@
+package main
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+//go:embed achievements/achievements.json
+var achievementsData []byte
+
+// Game events achievements listen to
+const (
+	EventOfferDeclined = "offer_declined" // Amount = the cash offer turned down
+	EventDeal          = "deal"           // Amount = the deal, EV and Multiplier of that offer
+	EventFinal         = "final"          // Amount = winnings, Swapped = swapped at the end
+	EventAllFood       = "all_food"       // every food tray besides the player's was opened
+	EventGameEnd       = "game_end"       // Amount = winnings
+)
+
+// GameEvent is something that happened in a game
+type GameEvent struct {
+	Kind       string
+	Amount     int
+	EV         int
+	Multiplier string // multiplier case applied to the offer, like "/5"
+	Swapped    bool
+}
+
+// AchievementDef is one entry of achievements/achievements.json. Its name
+// and description are the "achievement.<id>.name" and ".desc" messages.
+type AchievementDef struct {
+	ID         string  `json:"id"`
+	Icon       string  `json:"icon"`
+	Event      string  `json:"event"`
+	Goal       int     `json:"goal"` // times the event must happen, 1 if not set
+	MinAmount  int     `json:"minAmount"`
+	MaxEVRatio float64 `json:"maxEvRatio"` // amount must be below this share of the EV
+	Multiplier string  `json:"multiplier"`
+	Swapped    bool    `json:"swapped"`
+}
+
+// AchievementState is a profile's progress on one achievement
+type AchievementState struct {
+	Progress int    `json:"progress"`
+	Unlocked string `json:"unlocked,omitempty"` // date it was unlocked
+}
+
+var achievementDefs = loadAchievementDefs()
+
+func loadAchievementDefs() []AchievementDef {
+	var defs []AchievementDef
+	if err := json.Unmarshal(achievementsData, &defs); err != nil {
+		panic(fmt.Sprintf("achievements/achievements.json: %v", err))
+	}
+	for i := range defs {
+		if defs[i].Goal < 1 {
+			defs[i].Goal = 1
+		}
+	}
+	return defs
+}
+
+// Matches tells whether an event counts towards the achievement
+func (d AchievementDef) Matches(ev GameEvent) bool {
+	if ev.Kind != d.Event {
+		return false
+	}
+	if ev.Amount < d.MinAmount {
+		return false
+	}
+	if d.MaxEVRatio > 0 && (ev.EV <= 0 || float64(ev.Amount) >= d.MaxEVRatio*float64(ev.EV)) {
+		return false
+	}
+	if d.Multiplier != "" && ev.Multiplier != d.Multiplier {
+		return false
+	}
+	if d.Swapped && !ev.Swapped {
+		return false
+	}
+	return true
+}
+
+// Achievements are kept per profile, the player's name on the start screen
+func LoadAchievements(p fyne.Preferences, profile string) map[string]*AchievementState {
+	states := map[string]*AchievementState{}
+	if data := p.String("achievements." + profile); data != "" {
+		if err := json.Unmarshal([]byte(data), &states); err != nil {
+			fyne.LogError("Could not read achievements", err)
+		}
+	}
+	return states
+}
+
+func SaveAchievements(p fyne.Preferences, profile string, states map[string]*AchievementState) {
+	data, err := json.Marshal(states)
+	if err != nil {
+		fyne.LogError("Could not save achievements", err)
+		return
+	}
+	p.SetString("achievements."+profile, string(data))
+}
+
+// trackAchievements counts an event for every matching achievement of the
+// profile and returns the ones it unlocked
+func trackAchievements(p fyne.Preferences, profile string, ev GameEvent) []AchievementDef {
+	states := LoadAchievements(p, profile)
+	unlocked := []AchievementDef{}
+	for _, d := range achievementDefs {
+		if !d.Matches(ev) {
+			continue
+		}
+		s := states[d.ID]
+		if s == nil {
+			s = &AchievementState{}
+			states[d.ID] = s
+		}
+		if s.Unlocked != "" {
+			continue
+		}
+		s.Progress++
+		if s.Progress >= d.Goal {
+			s.Unlocked = time.Now().Format(dailyLayout)
+			unlocked = append(unlocked, d)
+		}
+	}
+	SaveAchievements(p, profile, states)
+	return unlocked
+}
+
+// achieve passes a game event on to the achievements and shows a toast
+// for each one unlocked
+func (g *Game) achieve(ev GameEvent) {
+	for _, d := range trackAchievements(fyne.CurrentApp().Preferences(), g.config.PlayerName, ev) {
+		g.showToast(d.Icon + " " + T("achievements.unlocked", T("achievement."+d.ID+".name")))
+	}
+}
+
+// showToast puts a note in the corner of the top bar for a few seconds.
+// It is part of the board, not a pop-up, so it never takes a tap meant for
+// the trays.
+func (g *Game) showToast(text string) {
+	if g.toast == nil {
+		return
+	}
+	if g.toastAnim != nil {
+		g.toastAnim.Stop()
+	}
+	g.toast.SetText(text)
+	g.toast.Show()
+	g.toastAnim = fyne.NewAnimation(3*time.Second, func(p float32) {
+		if p >= 1 {
+			g.toast.Hide()
+		}
+	})
+	g.toastAnim.Curve = fyne.AnimationLinear
+	g.toastAnim.Start()
+}
+
+// showAchievements lists every achievement with the profile's progress
+func showAchievements(w fyne.Window, profile string) {
+	states := LoadAchievements(fyne.CurrentApp().Preferences(), profile)
+	list := container.NewVBox()
+	for _, d := range achievementDefs {
+		status := fmt.Sprintf("%d/%d", 0, d.Goal)
+		icon := "🔒"
+		if s := states[d.ID]; s != nil {
+			status = fmt.Sprintf("%d/%d", s.Progress, d.Goal)
+			if s.Unlocked != "" {
+				status = T("achievements.unlocked_on", s.Unlocked)
+				icon = d.Icon
+			}
+		}
+		name := widget.NewLabelWithStyle(icon+" "+T("achievement."+d.ID+".name"), fyne.TextAlignLeading, fyne.TextStyle{Bold: true})
+		desc := widget.NewLabel(T("achievement."+d.ID+".desc") + " · " + status)
+		list.Add(container.NewVBox(name, desc))
+	}
+
+	who := profile
+	if who == "" {
+		who = T("achievements.guest")
+	}
+	content := container.NewBorder(widget.NewLabel(T("achievements.profile", who)), nil, nil, nil,
+		container.NewVScroll(list))
+	d := dialog.NewCustom(T("achievements.title"), T("close"), content, w)
+	d.Resize(fyne.NewSize(460, 480))
+	d.Show()
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"image/color"
+	"strconv"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/theme"
+)
+
+var (
+	lidColor    = color.NRGBA{R: 192, G: 192, B: 200, A: 255} // silver cloche
+	strikeColor = color.NRGBA{R: 220, G: 40, B: 40, A: 255}
+)
+
+// newLidReveal covers art (size x size) with a tray lid. The returned func
+// lifts the lid and slides the art into place; call it once the object is
+// shown. onDone is called when the art is in view.
+func newLidReveal(art fyne.CanvasObject, size float32, onDone func()) (fyne.CanvasObject, func()) {
+	box := fyne.NewSize(size, size)
+	art.Resize(box)
+
+	if currentSettings().ReduceMotion {
+		return container.NewGridWrap(box, container.NewWithoutLayout(art)), onDone
+	}
+
+	lid := canvas.NewRectangle(lidColor)
+	lid.CornerRadius = size / 4
+	lid.Resize(box)
+	handle := canvas.NewText(currentPack.TrayIcon, theme.Color(theme.ColorNameForeground))
+	handle.TextSize = size / 5
+	handle.Move(fyne.NewPos(size/2-handle.MinSize().Width/2, size/2-handle.MinSize().Height/2))
+
+	slide := size / 5
+	art.Move(fyne.NewPos(0, slide))
+	obj := container.NewGridWrap(box, container.NewWithoutLayout(art, lid, handle))
+
+	start := func() {
+		anim := fyne.NewAnimation(900*time.Millisecond, func(p float32) {
+			if p < 0.5 {
+				// lift: the lid shrinks upwards and the handle goes with it
+				left := size * (1 - p*2)
+				lid.Resize(fyne.NewSize(size, left))
+				handle.Move(fyne.NewPos(handle.Position().X, left/2-handle.MinSize().Height/2))
+				lid.Refresh()
+				handle.Refresh()
+				return
+			}
+			lid.Hide()
+			handle.Hide()
+			art.Move(fyne.NewPos(0, slide*(1-(p-0.5)*2)))
+			if p >= 1 {
+				onDone()
+			}
+		})
+		anim.Curve = fyne.AnimationEaseOut
+		anim.Start()
+	}
+	return obj, start
+}
+
+// showCountdown builds suspense with a "3, 2, 1" dialog before calling onDone
+func showCountdown(parent fyne.Window, from int, onDone func()) {
+	if currentSettings().ReduceMotion {
+		onDone()
+		return
+	}
+
+	number := canvas.NewText(strconv.Itoa(from), theme.Color(theme.ColorNamePrimary))
+	number.TextStyle.Bold = true
+	number.TextSize = 72
+	content := container.NewGridWrap(fyne.NewSize(180, 120), container.NewCenter(number))
+	d := dialog.NewCustomWithoutButtons(T("reveal.drumroll"), content, parent)
+	d.Show()
+
+	anim := fyne.NewAnimation(time.Duration(from)*time.Second, func(p float32) {
+		if p >= 1 {
+			d.Hide()
+			onDone()
+			return
+		}
+		// each number pops in big and shrinks during its second
+		step := p * float32(from)
+		n := from - int(step)
+		number.Text = strconv.Itoa(n)
+		number.TextSize = 48 + 48*(1-(step-float32(int(step))))
+		number.Refresh()
+	})
+	anim.Curve = fyne.AnimationLinear
+	anim.Start()
+}
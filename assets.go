@@ -0,0 +1,164 @@
+package main
+
+import (
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"image/color"
+	"io/fs"
+	"os"
+	"sort"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+)
+
+//go:embed images/*.jpg images/manifest.json
+var embeddedImages embed.FS
+
+// AssetStore resolves logical asset names (value_1000000, chef_3, food_ramen)
+// to image data. It searches a stack of layers from the top down: theme
+// pack art, then an optional override directory, then the images built into
+// the binary.
+type AssetStore struct {
+	layers []assetLayer // bottom first
+	cache  map[string]fyne.Resource
+}
+
+// assetLayer is one place images can come from
+type assetLayer struct {
+	fsys  fs.FS
+	files map[string]string // logical name -> file name in fsys
+}
+
+// baseAssets holds the built-in images and the override directory; assets
+// is what loadImage uses, baseAssets plus the current theme pack's art
+var (
+	baseAssets = mustLoadAssets("")
+	assets     = baseAssets
+)
+
+func mustLoadAssets(override string) *AssetStore {
+	a, err := NewAssetStore(override)
+	if err != nil {
+		panic(err)
+	}
+	return a
+}
+
+// readManifest decodes a manifest.json mapping asset names to files
+func readManifest(fsys fs.FS, name string) (map[string]string, error) {
+	data, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		return nil, err
+	}
+	files := map[string]string{}
+	if err := json.Unmarshal(data, &files); err != nil {
+		return nil, fmt.Errorf("asset manifest %s: %w", name, err)
+	}
+	return files, nil
+}
+
+// NewAssetStore reads the built-in manifest. Files in override with the
+// same names replace the built-in ones; if override has its own
+// manifest.json its entries are added on top.
+func NewAssetStore(override string) (*AssetStore, error) {
+	builtin, err := fs.Sub(embeddedImages, "images")
+	if err != nil {
+		return nil, err
+	}
+	files, err := readManifest(builtin, "manifest.json")
+	if err != nil {
+		return nil, err
+	}
+	a := &AssetStore{layers: []assetLayer{{builtin, files}}, cache: map[string]fyne.Resource{}}
+	if override == "" {
+		return a, nil
+	}
+
+	dir := os.DirFS(override)
+	custom := map[string]string{}
+	for name, file := range files {
+		custom[name] = file
+	}
+	extra, err := readManifest(dir, "manifest.json")
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("%s: %w", override, err)
+	}
+	for name, file := range extra {
+		custom[name] = file
+	}
+	return a.WithLayer(dir, custom), nil
+}
+
+// WithLayer returns a copy of the store with one more layer on top
+func (a *AssetStore) WithLayer(fsys fs.FS, files map[string]string) *AssetStore {
+	layers := append(append([]assetLayer{}, a.layers...), assetLayer{fsys, files})
+	return &AssetStore{layers: layers, cache: map[string]fyne.Resource{}}
+}
+
+// Resource returns the image for a logical name, or false if the name is
+// unknown or its file is missing
+func (a *AssetStore) Resource(name string) (fyne.Resource, bool) {
+	if res, ok := a.cache[name]; ok {
+		return res, true
+	}
+	for i := len(a.layers) - 1; i >= 0; i-- {
+		file, ok := a.layers[i].files[name]
+		if !ok {
+			continue
+		}
+		data, err := fs.ReadFile(a.layers[i].fsys, file)
+		if err != nil {
+			continue
+		}
+		res := fyne.NewStaticResource(file, data)
+		a.cache[name] = res
+		return res, true
+	}
+	return nil, false
+}
+
+// Missing lists manifest entries whose file cannot be found
+func (a *AssetStore) Missing() []string {
+	known := map[string]bool{}
+	for _, l := range a.layers {
+		for name := range l.files {
+			known[name] = true
+		}
+	}
+	missing := []string{}
+	for name := range known {
+		if _, ok := a.Resource(name); !ok {
+			missing = append(missing, name)
+		}
+	}
+	sort.Strings(missing)
+	return missing
+}
+
+// Asset names for the different kinds of pictures
+func valueAsset(value int) string { return fmt.Sprintf("value_%d", value) }
+func chefAsset(n int) string      { return fmt.Sprintf("chef_%d", n) }
+
+// Helper function to load an image by asset name, with a placeholder box
+// if the asset is missing
+func loadImage(name string, width, height float32) fyne.CanvasObject {
+	size := fyne.NewSize(scaled(width), scaled(height))
+	res, ok := assets.Resource(name)
+	if !ok {
+		box := canvas.NewRectangle(color.NRGBA{R: 128, G: 128, B: 128, A: 64})
+		box.StrokeColor = color.NRGBA{R: 128, G: 128, B: 128, A: 255}
+		box.StrokeWidth = 1
+		box.SetMinSize(size)
+		return container.NewStack(box, container.NewCenter(widget.NewLabel("🖼️ "+name)))
+	}
+
+	img := canvas.NewImageFromResource(res)
+	img.FillMode = canvas.ImageFillContain
+	img.SetMinSize(size)
+	return img
+}
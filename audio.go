@@ -0,0 +1,267 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"math"
+	"os"
+	"os/exec"
+	"sync"
+)
+
+const sampleRate = 22050
+
+// Cue is a sound effect played at a moment of the game
+type Cue int
+
+const (
+	CueTrayOpen Cue = iota
+	CuePhoneRing
+	CueBonusPick
+	CueDealAccepted
+	CueFinalReveal
+)
+
+// AudioBackend plays WAV data. Play must not block the UI.
+type AudioBackend interface {
+	Play(wav []byte)
+	Loop(wav []byte) // replaces any music already looping
+	StopLoop()
+}
+
+// nullAudio plays nothing; used when no player is available and in tests
+type nullAudio struct{}
+
+func (nullAudio) Play([]byte) {}
+func (nullAudio) Loop([]byte) {}
+func (nullAudio) StopLoop()   {}
+
+// execAudio hands WAV files to a command line player (paplay, aplay, afplay)
+type execAudio struct {
+	player   string
+	args     []string
+	mu       sync.Mutex
+	stopLoop context.CancelFunc
+}
+
+// newAudioBackend picks the first player found on the system, or nullAudio
+func newAudioBackend() AudioBackend {
+	players := []struct {
+		name string
+		args []string
+	}{
+		{"paplay", nil},
+		{"aplay", []string{"-q"}},
+		{"afplay", nil},
+	}
+	for _, p := range players {
+		if path, err := exec.LookPath(p.name); err == nil {
+			return &execAudio{player: path, args: p.args}
+		}
+	}
+	return nullAudio{}
+}
+
+func (a *execAudio) run(ctx context.Context, wav []byte) error {
+	f, err := os.CreateTemp("", "mealnomeal-*.wav")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.Write(wav); err != nil {
+		f.Close()
+		return err
+	}
+	f.Close()
+
+	args := append(append([]string{}, a.args...), f.Name())
+	return exec.CommandContext(ctx, a.player, args...).Run()
+}
+
+func (a *execAudio) Play(wav []byte) {
+	go a.run(context.Background(), wav)
+}
+
+func (a *execAudio) Loop(wav []byte) {
+	a.StopLoop()
+	ctx, cancel := context.WithCancel(context.Background())
+	a.mu.Lock()
+	a.stopLoop = cancel
+	a.mu.Unlock()
+
+	go func() {
+		for ctx.Err() == nil {
+			if err := a.run(ctx, wav); err != nil && ctx.Err() == nil {
+				return // player is broken, don't spin
+			}
+		}
+	}()
+}
+
+func (a *execAudio) StopLoop() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.stopLoop != nil {
+		a.stopLoop()
+		a.stopLoop = nil
+	}
+}
+
+// Sound turns game events into synthesized cues. A nil *Sound is silent.
+type Sound struct {
+	backend AudioBackend
+	volume  float64
+	muted   bool
+	music   bool
+	playing float64 // volume the music loop runs at, 0 when stopped
+}
+
+// gameSound is shared by every game in the window; main swaps in a real backend
+var gameSound = NewSound(nullAudio{})
+
+func NewSound(backend AudioBackend) *Sound {
+	return &Sound{backend: backend, volume: 1.0}
+}
+
+// Apply takes volume, mute and music from the settings
+func (s *Sound) Apply(st *Settings) {
+	if s == nil {
+		return
+	}
+	s.volume = st.Volume
+	s.muted = st.Muted
+	s.music = st.Music
+
+	want := 0.0
+	if !s.muted && s.music {
+		want = s.volume
+	}
+	if want == s.playing {
+		return
+	}
+	s.playing = want
+	if want == 0 {
+		s.backend.StopLoop()
+	} else {
+		s.backend.Loop(encodeWAV(want*0.3, musicLoop()))
+	}
+}
+
+// Play a cue at its normal pitch
+func (s *Sound) Play(c Cue) {
+	s.play(c, 1.0)
+}
+
+// TrayOpened plays the open cue pitched by the value found: low values ring
+// high (good for the player), big values thud low. Food items get the lowest.
+func (s *Sound) TrayOpened(value int) {
+	pitch := 0.5
+	for i, v := range VALUES {
+		if v == value {
+			pitch = 2.0 - 1.5*float64(i)/float64(len(VALUES)-1)
+			break
+		}
+	}
+	s.play(CueTrayOpen, pitch)
+}
+
+func (s *Sound) play(c Cue, pitch float64) {
+	if s == nil || s.muted || s.volume <= 0 {
+		return
+	}
+	s.backend.Play(encodeWAV(s.volume, cueSamples(c, pitch)))
+}
+
+// note is a sine tone with a short attack and release
+func note(freq, seconds float64) []float64 {
+	n := int(seconds * sampleRate)
+	out := make([]float64, n)
+	ramp := sampleRate / 100 // 10ms
+	for i := range out {
+		env := 1.0
+		if i < ramp {
+			env = float64(i) / float64(ramp)
+		} else if n-i < ramp {
+			env = float64(n-i) / float64(ramp)
+		}
+		out[i] = env * math.Sin(2*math.Pi*freq*float64(i)/sampleRate)
+	}
+	return out
+}
+
+func rest(seconds float64) []float64 {
+	return make([]float64, int(seconds*sampleRate))
+}
+
+func join(parts ...[]float64) []float64 {
+	out := []float64{}
+	for _, p := range parts {
+		out = append(out, p...)
+	}
+	return out
+}
+
+// ring is the classic two-tone phone burst
+func ring(seconds float64) []float64 {
+	a, b := note(440, seconds), note(480, seconds)
+	for i := range a {
+		gate := 1.0
+		if int(float64(i)/sampleRate*40)%2 == 1 {
+			gate = 0.4 // 20Hz warble
+		}
+		a[i] = (a[i] + b[i]) / 2 * gate
+	}
+	return a
+}
+
+func cueSamples(c Cue, pitch float64) []float64 {
+	switch c {
+	case CueTrayOpen:
+		return join(note(523*pitch, 0.08), note(784*pitch, 0.12))
+	case CuePhoneRing:
+		return join(ring(0.4), rest(0.2), ring(0.4))
+	case CueBonusPick:
+		return join(note(659, 0.07), note(784, 0.07), note(1047, 0.12))
+	case CueDealAccepted:
+		return join(note(523, 0.12), note(659, 0.12), note(784, 0.12), note(1047, 0.35))
+	case CueFinalReveal:
+		return join(note(392, 0.15), note(523, 0.15), note(659, 0.15), note(784, 0.5))
+	}
+	return nil
+}
+
+// musicLoop is a slow, quiet kitchen tune
+func musicLoop() []float64 {
+	melody := []float64{262, 330, 392, 330, 294, 349, 440, 349, 262, 330, 392, 523, 392, 330, 294, 262}
+	parts := [][]float64{}
+	for _, f := range melody {
+		parts = append(parts, note(f, 0.35), rest(0.05))
+	}
+	return join(parts...)
+}
+
+// encodeWAV turns samples in [-1, 1] into a 16-bit mono WAV file
+func encodeWAV(volume float64, samples []float64) []byte {
+	var buf bytes.Buffer
+	dataLen := uint32(len(samples) * 2)
+
+	buf.WriteString("RIFF")
+	binary.Write(&buf, binary.LittleEndian, 36+dataLen)
+	buf.WriteString("WAVEfmt ")
+	binary.Write(&buf, binary.LittleEndian, uint32(16))           // fmt chunk size
+	binary.Write(&buf, binary.LittleEndian, uint16(1))            // PCM
+	binary.Write(&buf, binary.LittleEndian, uint16(1))            // mono
+	binary.Write(&buf, binary.LittleEndian, uint32(sampleRate))   // sample rate
+	binary.Write(&buf, binary.LittleEndian, uint32(sampleRate*2)) // byte rate
+	binary.Write(&buf, binary.LittleEndian, uint16(2))            // block align
+	binary.Write(&buf, binary.LittleEndian, uint16(16))           // bits per sample
+	buf.WriteString("data")
+	binary.Write(&buf, binary.LittleEndian, dataLen)
+
+	for _, s := range samples {
+		v := math.Max(-1, math.Min(1, s*volume))
+		binary.Write(&buf, binary.LittleEndian, int16(v*math.MaxInt16))
+	}
+	return buf.Bytes()
+}
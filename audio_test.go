@@ -0,0 +1,108 @@
+package main
+
+import (
+	"encoding/binary"
+	"math"
+	"testing"
+)
+
+// recordAudio keeps what it was asked to play
+type recordAudio struct {
+	played [][]byte
+	looped []byte
+}
+
+func (a *recordAudio) Play(wav []byte) { a.played = append(a.played, wav) }
+func (a *recordAudio) Loop(wav []byte) { a.looped = wav }
+func (a *recordAudio) StopLoop()       { a.looped = nil }
+
+// wavSamples reads the 16-bit samples back out of an encoded WAV
+func wavSamples(t *testing.T, wav []byte) []int16 {
+	t.Helper()
+	if len(wav) < 44 || string(wav[:4]) != "RIFF" || string(wav[8:16]) != "WAVEfmt " || string(wav[36:40]) != "data" {
+		t.Fatalf("not a WAV header: % x", wav[:min(len(wav), 44)])
+	}
+	le := binary.LittleEndian
+	dataLen := le.Uint32(wav[40:44])
+	if le.Uint32(wav[4:8]) != 36+dataLen || int(dataLen) != len(wav)-44 {
+		t.Fatalf("sizes in the header do not match the %d bytes", len(wav))
+	}
+	if le.Uint16(wav[20:22]) != 1 || le.Uint16(wav[22:24]) != 1 || le.Uint32(wav[24:28]) != sampleRate || le.Uint16(wav[34:36]) != 16 {
+		t.Fatalf("not 16-bit mono PCM at %d Hz: % x", sampleRate, wav[20:36])
+	}
+	out := make([]int16, dataLen/2)
+	for i := range out {
+		out[i] = int16(le.Uint16(wav[44+2*i:]))
+	}
+	return out
+}
+
+func TestEncodeWAV(t *testing.T) {
+	tests := []struct {
+		name    string
+		volume  float64
+		samples []float64
+		want    []int16
+	}{
+		{"silence", 1, nil, []int16{}},
+		{"full scale", 1, []float64{0, 1, -1}, []int16{0, math.MaxInt16, -math.MaxInt16}},
+		{"half volume", 0.5, []float64{1, -1}, []int16{math.MaxInt16 / 2, -math.MaxInt16 / 2}},
+		{"clipped", 2, []float64{0.75, -0.75}, []int16{math.MaxInt16, -math.MaxInt16}},
+		{"muted", 0, []float64{1, -1}, []int16{0, 0}},
+	}
+	for _, tt := range tests {
+		got := wavSamples(t, encodeWAV(tt.volume, tt.samples))
+		if len(got) != len(tt.want) {
+			t.Errorf("%s: %d samples, want %d", tt.name, len(got), len(tt.want))
+			continue
+		}
+		for i := range got {
+			if d := int(got[i]) - int(tt.want[i]); d < -1 || d > 1 {
+				t.Errorf("%s: sample %d = %d, want %d", tt.name, i, got[i], tt.want[i])
+			}
+		}
+	}
+}
+
+func TestSoundFollowsSettings(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings Settings
+		plays    bool
+		music    bool
+	}{
+		{"on", Settings{Volume: 0.8, Music: true}, true, true},
+		{"no music", Settings{Volume: 0.8}, true, false},
+		{"muted", Settings{Volume: 0.8, Muted: true, Music: true}, false, false},
+		{"volume down", Settings{Music: true}, false, false},
+	}
+	for _, tt := range tests {
+		a := &recordAudio{}
+		s := NewSound(a)
+		s.Apply(&tt.settings)
+		s.Play(CueBonusPick)
+		if plays := len(a.played) == 1; plays != tt.plays {
+			t.Errorf("%s: played %d cues", tt.name, len(a.played))
+		}
+		if music := a.looped != nil; music != tt.music {
+			t.Errorf("%s: music playing %v, want %v", tt.name, music, tt.music)
+		}
+		if tt.plays {
+			peak := 0
+			for _, v := range wavSamples(t, a.played[0]) {
+				peak = max(peak, int(v))
+			}
+			if want := int(tt.settings.Volume * math.MaxInt16); peak > want || peak < want*9/10 {
+				t.Errorf("%s: peak %d, want about %d", tt.name, peak, want)
+			}
+		}
+	}
+
+	// without a player the game plays on in silence
+	s := NewSound(nullAudio{})
+	s.Apply(&Settings{Volume: 1, Music: true})
+	s.Play(CueFinalReveal)
+	s.TrayOpened(1000000)
+	var none *Sound
+	none.Play(CueTrayOpen)
+}
@@ -1,29 +1,82 @@
 package main
 
 import (
+	"fmt"
 	"math/rand"
-	"time"
 )
 
 type Chef struct {
-	r *rand.Rand
+	r          *rand.Rand
+	src        *countedSource // behind r, so a resumed game goes on with the same offers
+	art        []string       // asset names of the banker pictures
+	minFactor  float64        // offers are average * a factor in [minFactor, maxFactor)
+	maxFactor  float64
+	swapChance float64
 }
 
-func NewChef() *Chef {
-	return &Chef{r: rand.New(rand.NewSource(time.Now().UnixNano()))}
+func NewChef(seed int64) *Chef {
+	src := newCountedSource(seed)
+	return &Chef{
+		r:          rand.New(src),
+		src:        src,
+		minFactor:  0.6,
+		maxFactor:  0.95,
+		swapChance: 0.20,
+	}
+}
+
+// Tune sets the offer range and swap chance from a difficulty preset
+func (b *Chef) Tune(d Difficulty) {
+	b.minFactor = d.MinFactor
+	b.maxFactor = d.MaxFactor
+	b.swapChance = d.SwapChance
+}
+
+// Scale makes every offer more (>1) or less (<1) generous
+func (b *Chef) Scale(generosity float64) {
+	b.minFactor *= generosity
+	b.maxFactor *= generosity
+}
+
+// Override sets the parts of the Chef given on the command line or in the
+// config file. Factors that would put the lowest offer above the highest
+// one are left out and the preset's range is kept.
+func (b *Chef) Override(o ChefOverrides) error {
+	low, high := b.minFactor, b.maxFactor
+	if o.MinFactor != nil {
+		low = *o.MinFactor
+	}
+	if o.MaxFactor != nil {
+		high = *o.MaxFactor
+	}
+	if o.SwapChance != nil {
+		b.swapChance = *o.SwapChance
+	}
+	if low > high {
+		return fmt.Errorf("chef min_factor %.2f is above max_factor %.2f", low, high)
+	}
+	b.minFactor, b.maxFactor = low, high
+	return nil
+}
+
+// chefFor is the Chef for a game with cfg: the difficulty preset scaled by
+// the generosity, with the overrides on top
+func chefFor(cfg GameConfig, seed int64) (*Chef, error) {
+	c := NewChef(seed)
+	c.Tune(difficultyByName(cfg.Difficulty))
+	c.Scale(cfg.Generosity)
+	return c, c.Override(cfg.Chef)
 }
 
 // OfferSwap randomly decides whether chef offers a swap (small chance)
 func (b *Chef) OfferSwap() bool {
-	// 20% chance to offer swap (tunable)
-	return b.r.Float64() < 0.20
+	return b.r.Float64() < b.swapChance
 }
 
-// CalculateOffer computes a chef offer from remaining values.
-// This uses average * factor to mimic a chef algorithm.
-func (b *Chef) CalculateOffer(values []int) int {
-	sum := 0
-	count := 0
+// moneyAverage is the average of the cash values, food (-1) left out. The
+// Chef's offers are based on it and the offer history shows it as the EV.
+func moneyAverage(values []int) (avg float64, ok bool) {
+	sum, count := 0, 0
 	for _, v := range values {
 		if v > 0 {
 			sum += v
@@ -31,15 +84,41 @@ func (b *Chef) CalculateOffer(values []int) int {
 		}
 	}
 	if count == 0 {
+		return 0, false
+	}
+	return float64(sum) / float64(count), true
+}
+
+// CalculateOffer computes a chef offer from remaining values.
+// This uses average * factor to mimic a chef algorithm.
+func (b *Chef) CalculateOffer(values []int) int {
+	avg, ok := moneyAverage(values)
+	if !ok {
 		return 0
 	}
-	avg := float64(sum) / float64(count)
-	// factor between 0.6 and 0.95 (randomized)
-	factor := 0.6 + b.r.Float64()*0.35
-	return int(avg * factor)
+	// factor between minFactor and maxFactor (randomized)
+	factor := b.minFactor + b.r.Float64()*(b.maxFactor-b.minFactor)
+	// the Chef always offers something, even for a board of pennies
+	if offer := int(avg * factor); offer > 0 {
+		return offer
+	}
+	return 1
+}
+
+// OfferRange is the lowest and highest offer CalculateOffer can make
+func (b *Chef) OfferRange(values []int) (low, high int) {
+	avg, ok := moneyAverage(values)
+	if !ok {
+		return 0, 0
+	}
+	return max(1, int(avg*b.minFactor)), max(1, int(avg*b.maxFactor))
 }
 
-// GetRandomChefImage returns a random chef image number (27-50)
-func (c *Chef) GetRandomChefImage() int {
-	return 27 + c.r.Intn(24) // Random between 27 and 50
+// GetRandomChefImage returns a random banker picture (chef_1 - chef_24
+// unless the theme pack has its own)
+func (c *Chef) GetRandomChefImage() string {
+	if len(c.art) == 0 {
+		return chefAsset(1 + c.r.Intn(24))
+	}
+	return c.art[c.r.Intn(len(c.art))]
 }
@@ -0,0 +1,69 @@
+package main
+
+import "testing"
+
+func TestCalculateOffer(t *testing.T) {
+	tests := []struct {
+		name     string
+		values   []int
+		min, max float64
+		want     int
+	}{
+		{"no values", nil, 0.6, 0.95, 0},
+		{"only food", []int{-1, -1}, 0.6, 0.95, 0},
+		{"fixed factor", []int{100, 300}, 1, 1, 200},
+		{"half of average", []int{1000, 3000}, 0.5, 0.5, 1000},
+		{"food is skipped", []int{-1, 400, 800}, 1, 1, 600},
+		{"rounds down", []int{1, 2}, 1, 1, 1},
+		{"never zero", []int{1}, 0.3, 0.3, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewChef(1)
+			c.minFactor, c.maxFactor = tt.min, tt.max
+			if got := c.CalculateOffer(tt.values); got != tt.want {
+				t.Errorf("CalculateOffer(%v) = %d, want %d", tt.values, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCalculateOfferStaysInRange(t *testing.T) {
+	values := []int{1, 100, 1000, 1000000}
+	avg := float64(1+100+1000+1000000) / 4
+	for _, d := range difficulties {
+		c := NewChef(7)
+		c.Tune(d)
+		for i := 0; i < 200; i++ {
+			got := c.CalculateOffer(values)
+			if float64(got) < avg*d.MinFactor-1 || float64(got) > avg*d.MaxFactor {
+				t.Fatalf("%s: offer %d outside [%.0f, %.0f]", d.Name, got, avg*d.MinFactor, avg*d.MaxFactor)
+			}
+		}
+	}
+}
+
+func TestScaleChangesFactors(t *testing.T) {
+	c := NewChef(1)
+	c.Scale(2)
+	if c.minFactor != 1.2 || c.maxFactor != 1.9 {
+		t.Errorf("Scale(2) gave [%v, %v), want [1.2, 1.9)", c.minFactor, c.maxFactor)
+	}
+}
+
+func TestOfferRangeHoldsEveryOffer(t *testing.T) {
+	values := []int{-1, 5, 750, 50000}
+	c := NewChef(3)
+	low, high := c.OfferRange(values)
+	if low > high || low < 1 {
+		t.Fatalf("OfferRange = %d..%d", low, high)
+	}
+	for i := 0; i < 200; i++ {
+		if got := c.CalculateOffer(values); got < low || got > high {
+			t.Fatalf("offer %d outside OfferRange %d..%d", got, low, high)
+		}
+	}
+	if low, high := c.OfferRange([]int{-1}); low != 0 || high != 0 {
+		t.Errorf("OfferRange of food only = %d..%d, want 0..0", low, high)
+	}
+}
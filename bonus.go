@@ -1,10 +1,8 @@
 package main
 
 import (
-	"fmt"
 	"math/rand"
 	"strconv"
-	"time"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/container"
@@ -14,25 +12,77 @@ import (
 
 type BonusManager struct {
 	random           *rand.Rand
+	src              *countedSource // behind random, see Chef.src
 	multiplierActive bool
 	additiveActive   bool
 	multiplierUsed   bool
 	additiveUsed     bool
 	multiplier       float64
+	multiplierCase   string // case the multiplier came from, like "/5"
 	additive         int
+	picks            []string // cases chosen this game, in order
+	sound            *Sound
+
+	// spread of the options in the bonus cases
+	maxQuotient    int     // multipliers are *2../maxQuotient or /2../maxQuotient
+	multiplyChance float64 // chance a multiplier case is * rather than /
+	maxAdditive    int     // additives are 100..maxAdditive in steps of 100
+	plusChance     float64 // chance an additive case is + rather than -
 }
 
-func NewBonusManager() *BonusManager {
-	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+func NewBonusManager(seed int64) *BonusManager {
+	src := newCountedSource(seed)
+	r := rand.New(src)
 	return &BonusManager{
 		random:           r,
+		src:              src,
 		multiplierActive: r.Intn(2) == 0, // 50%
 		additiveActive:   r.Intn(2) == 0, // 50%
 		multiplier:       1.0,
 		additive:         0,
+		maxQuotient:      5,
+		multiplyChance:   0.5,
+		maxAdditive:      2000,
+		plusChance:       0.5,
 	}
 }
 
+// Tune sets the spread of the bonus options from a difficulty preset
+func (bm *BonusManager) Tune(d Difficulty) {
+	bm.maxQuotient = d.MaxQuotient
+	bm.multiplyChance = d.MultiplyChance
+	bm.maxAdditive = d.MaxAdditive
+	bm.plusChance = d.PlusChance
+}
+
+// multiplierOptions deals the five multiplier cases
+func (bm *BonusManager) multiplierOptions() []string {
+	options := []string{}
+	for i := 0; i < 5; i++ {
+		q := bm.random.Intn(bm.maxQuotient-1) + 2 // 2..maxQuotient
+		if bm.random.Float64() < bm.multiplyChance {
+			options = append(options, "*"+strconv.Itoa(q))
+		} else {
+			options = append(options, "/"+strconv.Itoa(q))
+		}
+	}
+	return options
+}
+
+// additiveOptions deals the ten additive cases
+func (bm *BonusManager) additiveOptions() []string {
+	options := []string{}
+	for i := 0; i < 10; i++ {
+		val := (bm.random.Intn(bm.maxAdditive/100) + 1) * 100 // 100..maxAdditive
+		if bm.random.Float64() < bm.plusChance {
+			options = append(options, "+"+strconv.Itoa(val))
+		} else {
+			options = append(options, "-"+strconv.Itoa(val))
+		}
+	}
+	return options
+}
+
 func (bm *BonusManager) HasMultiplier() bool { return bm.multiplierActive && !bm.multiplierUsed }
 func (bm *BonusManager) HasAdditive() bool   { return bm.additiveActive && !bm.additiveUsed }
 
@@ -46,11 +96,15 @@ func (bm *BonusManager) GetBonusDescription() string {
 	parts := []string{}
 
 	if bm.multiplier != 1.0 {
-		parts = append(parts, fmt.Sprintf("Multiplier: %.2fx", bm.multiplier))
+		parts = append(parts, T("bonus.multiplier", bm.multiplier))
 	}
 
 	if bm.additive != 0 {
-		parts = append(parts, fmt.Sprintf("Additive: %+d", bm.additive))
+		amount := Money(bm.additive)
+		if bm.additive > 0 {
+			amount = "+" + amount
+		}
+		parts = append(parts, T("bonus.additive", amount))
 	}
 
 	if len(parts) == 0 {
@@ -60,7 +114,7 @@ func (bm *BonusManager) GetBonusDescription() string {
 	result := ""
 	for i, part := range parts {
 		if i > 0 {
-			result += " and "
+			result += T("bonus.and")
 		}
 		result += part
 	}
@@ -75,17 +129,7 @@ func (bm *BonusManager) TriggerMultiplierWithCallback(parent fyne.Window, onComp
 		return
 	}
 
-	options := []string{}
-	for i := 0; i < 5; i++ {
-		q := bm.random.Intn(4) + 2 // 2–5
-		if bm.random.Intn(2) == 0 {
-			options = append(options, "*"+strconv.Itoa(q))
-		} else {
-			options = append(options, "/"+strconv.Itoa(q))
-		}
-	}
-
-	bm.showBonusChoiceDialog(parent, "Multiplier Bonus", options, func(choice string) {
+	bm.showBonusChoiceDialog(parent, T("bonus.multiplier.title"), bm.multiplierOptions(), func(choice string) {
 		if choice[0] == '*' {
 			q, _ := strconv.Atoi(choice[1:])
 			bm.multiplier = float64(q)
@@ -93,10 +137,12 @@ func (bm *BonusManager) TriggerMultiplierWithCallback(parent fyne.Window, onComp
 			q, _ := strconv.Atoi(choice[1:])
 			bm.multiplier = 1.0 / float64(q)
 		}
+		bm.multiplierCase = choice
 		bm.multiplierUsed = true
+		bm.picks = append(bm.picks, choice)
 
 		// Show result, then call onComplete
-		d := dialog.NewInformation("Multiplier Bonus Selected", fmt.Sprintf("You got: %s", choice), parent)
+		d := dialog.NewInformation(T("bonus.multiplier.selected"), T("bonus.you_got", choice), parent)
 		d.SetOnClosed(func() {
 			if onComplete != nil {
 				onComplete()
@@ -114,17 +160,7 @@ func (bm *BonusManager) TriggerAdditiveWithCallback(parent fyne.Window, onComple
 		return
 	}
 
-	options := []string{}
-	for i := 0; i < 10; i++ {
-		val := (bm.random.Intn(20) + 1) * 100 // 100–2000
-		if bm.random.Intn(2) == 0 {
-			options = append(options, "+"+strconv.Itoa(val))
-		} else {
-			options = append(options, "-"+strconv.Itoa(val))
-		}
-	}
-
-	bm.showBonusChoiceDialog(parent, "Additive Bonus", options, func(choice string) {
+	bm.showBonusChoiceDialog(parent, T("bonus.additive.title"), bm.additiveOptions(), func(choice string) {
 		if choice[0] == '+' {
 			v, _ := strconv.Atoi(choice[1:])
 			bm.additive = v
@@ -133,9 +169,10 @@ func (bm *BonusManager) TriggerAdditiveWithCallback(parent fyne.Window, onComple
 			bm.additive = -v
 		}
 		bm.additiveUsed = true
+		bm.picks = append(bm.picks, choice)
 
 		// Show result, then call onComplete
-		d := dialog.NewInformation("Additive Bonus Selected", fmt.Sprintf("You got: %s", choice), parent)
+		d := dialog.NewInformation(T("bonus.additive.selected"), T("bonus.you_got", choice), parent)
 		d.SetOnClosed(func() {
 			if onComplete != nil {
 				onComplete()
@@ -160,6 +197,7 @@ func (bm *BonusManager) Apply(offer int) int {
 	if bm.multiplier != 1.0 {
 		modified *= bm.multiplier
 		bm.multiplier = 1.0
+		bm.multiplierCase = ""
 	}
 
 	if bm.additive != 0 {
@@ -174,8 +212,12 @@ func (bm *BonusManager) Apply(offer int) int {
 }
 
 func (bm *BonusManager) showBonusChoiceDialog(parent fyne.Window, title string, options []string, onChosen func(choice string)) {
-	// build a grid of buttons (cases)
-	grid := container.NewGridWithColumns(5)
+	// build a grid of buttons (cases), fewer per row on a phone
+	columns := 5
+	if mobileUI {
+		columns = 2
+	}
+	grid := container.NewGridWithColumns(columns)
 	var chosen string
 
 	// declare dlg here so button closures can call dlg.Hide()
@@ -183,9 +225,10 @@ func (bm *BonusManager) showBonusChoiceDialog(parent fyne.Window, title string,
 
 	for i, opt := range options {
 		optCopy := opt // capture loop variable
-		btn := widget.NewButton(fmt.Sprintf("Case %d", i+1), func() {
+		btn := widget.NewButton(T("bonus.case", i+1), func() {
 			// select this option
 			chosen = optCopy
+			bm.sound.Play(CueBonusPick)
 			if dlg != nil {
 				dlg.Hide()
 				// Call onChosen immediately after hiding
@@ -194,15 +237,14 @@ func (bm *BonusManager) showBonusChoiceDialog(parent fyne.Window, title string,
 				}
 			}
 		})
-		btn.Resize(fyne.NewSize(80, 40))
-		grid.Add(btn)
+		grid.Add(touchButton(btn))
 	}
 
 	// create the custom dialog (removed the confirm buttons since we select by clicking cases)
-	dlg = dialog.NewCustom(title, "Cancel", grid, parent)
+	dlg = dialog.NewCustom(title, T("cancel"), grid, parent)
 	dlg.Show()
 }
 
 func (bm *BonusManager) showBonusResult(parent fyne.Window, title, bonus string) {
-	dialog.ShowInformation(title, fmt.Sprintf("You got: %s", bonus), parent)
+	dialog.ShowInformation(title, T("bonus.you_got", bonus), parent)
 }
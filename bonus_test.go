@@ -0,0 +1,52 @@
+package main
+
+import "testing"
+
+func TestBonusApply(t *testing.T) {
+	tests := []struct {
+		name       string
+		multiplier float64
+		additive   int
+		offer      int
+		want       int
+	}{
+		{"no bonus", 1, 0, 5000, 5000},
+		{"times two", 2, 0, 5000, 10000},
+		{"divided by four", 0.25, 0, 5000, 1250},
+		{"plus", 1, 300, 5000, 5300},
+		{"minus", 1, -300, 5000, 4700},
+		{"multiplier before additive", 2, 100, 5000, 10100},
+		{"never below one", 1, -2000, 500, 1},
+		{"divide then minus", 0.5, -2000, 1000, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bm := NewBonusManager(1)
+			bm.multiplier, bm.additive = tt.multiplier, tt.additive
+			if got := bm.Apply(tt.offer); got != tt.want {
+				t.Errorf("Apply(%d) = %d, want %d", tt.offer, got, tt.want)
+			}
+			if bm.HasPendingBonus() {
+				t.Error("bonus still pending after Apply")
+			}
+			if got := bm.Apply(tt.offer); got != tt.offer {
+				t.Errorf("second Apply(%d) = %d, the bonus should only count once", tt.offer, got)
+			}
+		})
+	}
+}
+
+func TestBonusOptions(t *testing.T) {
+	for _, d := range difficulties {
+		bm := NewBonusManager(3)
+		bm.Tune(d)
+		for _, opt := range bm.multiplierOptions() {
+			if opt[0] != '*' && opt[0] != '/' {
+				t.Errorf("%s: bad multiplier option %q", d.Name, opt)
+			}
+		}
+		if n := len(bm.additiveOptions()); n != 10 {
+			t.Errorf("%s: %d additive options, want 10", d.Name, n)
+		}
+	}
+}
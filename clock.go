@@ -0,0 +1,131 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/widget"
+)
+
+// Clock counts down each pick or decision in timed mode. When a turn's time
+// is up it eats into the time bank, and only when that is empty too does
+// the timeout fire. A nil *Clock does nothing, so untimed games skip it.
+type Clock struct {
+	label   *widget.Label
+	left    time.Duration // left for the current turn
+	bank    time.Duration // reserve shared by the whole game
+	running bool
+	turn    int // bumped on every start/stop so stale tickers give up
+}
+
+func NewClock(bank time.Duration) *Clock {
+	c := &Clock{label: widget.NewLabel(""), bank: bank}
+	c.show()
+	return c
+}
+
+// Start counts down turn for the next move and calls onTimeout if the
+// turn and the bank both run out first
+func (c *Clock) Start(turn time.Duration, onTimeout func()) {
+	if c == nil {
+		return
+	}
+	c.turn++
+	id := c.turn
+	c.left = turn
+	c.running = true
+	c.show()
+
+	go func() {
+		ticker := time.NewTicker(time.Second)
+		defer ticker.Stop()
+		for range ticker.C {
+			done := false
+			fyne.DoAndWait(func() {
+				if id != c.turn {
+					done = true
+					return
+				}
+				if c.left > 0 {
+					c.left -= time.Second
+				} else if c.bank > 0 {
+					c.bank -= time.Second
+				}
+				if c.left <= 0 && c.bank <= 0 {
+					c.Stop()
+					done = true
+					onTimeout()
+					return
+				}
+				c.show()
+			})
+			if done {
+				return
+			}
+		}
+	}()
+}
+
+// Stop ends the current turn; time left over is not kept
+func (c *Clock) Stop() {
+	if c == nil {
+		return
+	}
+	c.turn++
+	c.running = false
+	c.show()
+}
+
+func (c *Clock) show() {
+	text := "⏱ –"
+	if c.running {
+		text = fmt.Sprintf("⏱ %ds", int(c.left.Seconds()))
+	}
+	if c.bank > 0 {
+		text += " · " + T("clock.bank", int(c.bank.Seconds()))
+	}
+	c.label.SetText(text)
+}
+
+// Helpers to run the clock for the two kinds of moves
+
+func (g *Game) startPickClock() {
+	g.waiting = false
+	if g.clock == nil || g.playingOut || g.getUnopenedCount() == 0 {
+		return
+	}
+	g.clock.Start(time.Duration(g.config.PickSeconds)*time.Second, func() {
+		if activeGame != g {
+			return
+		}
+		g.pickRandomTray()
+	})
+}
+
+func (g *Game) startDecisionClock(onTimeout func()) {
+	if g.clock == nil || g.playingOut {
+		return
+	}
+	g.clock.Start(time.Duration(g.config.DecisionSeconds)*time.Second, func() {
+		if activeGame != g {
+			return
+		}
+		onTimeout()
+	})
+}
+
+// pickRandomTray is what happens when the pick clock runs out
+func (g *Game) pickRandomTray() {
+	options := []int{}
+	for i, b := range g.gridButtons {
+		if i != g.playerTray && !b.Disabled() {
+			options = append(options, i)
+		}
+	}
+	if len(options) == 0 {
+		return
+	}
+	g.onTrayClicked(fyne.CurrentApp(), options[rand.Intn(len(options))])
+}
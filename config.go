@@ -0,0 +1,104 @@
+package main
+
+import (
+	"strconv"
+
+	"fyne.io/fyne/v2"
+)
+
+// GameConfig is what the player sets up on the start screen
+type GameConfig struct {
+	PlayerName  string
+	Difficulty  string  // name of the preset, see difficulties
+	NumTrays    int     // 6..26
+	Generosity  float64 // scales the Chef's offers, 1 = normal
+	BonusChance float64 // chance per offer that the bonus round starts
+	MaxItems    int     // up to this many trays hide a food item
+	Seed        int64   // 0 = a new random board every game
+
+	// Timed mode: seconds for each tray pick and each Chef decision, plus a
+	// bank of extra seconds used up once a turn's time runs out
+	TimedMode       bool
+	PickSeconds     int
+	DecisionSeconds int
+	TimeBank        int
+
+	HostMode     bool // a second window for the host, see showHostConsole
+	ManualBanker bool // a person plays the Chef in a second window, see askBanker
+
+	// From the command line or config file only, see Options
+	OfferEvery int           // the Chef calls after every this many trays
+	Chef       ChefOverrides // changes to the preset's Chef
+}
+
+func DefaultGameConfig() GameConfig {
+	return GameConfig{
+		Difficulty:  "normal",
+		NumTrays:    NUM_TRAYS,
+		Generosity:  1.0,
+		BonusChance: 0.30,
+		MaxItems:    3,
+
+		PickSeconds:     15,
+		DecisionSeconds: 10,
+		TimeBank:        30,
+
+		OfferEvery: 3,
+	}
+}
+
+func LoadGameConfig(p fyne.Preferences) GameConfig {
+	d := DefaultGameConfig()
+	seed, _ := strconv.ParseInt(p.String("config.seed"), 10, 64)
+	return GameConfig{
+		PlayerName:  p.String("config.playerName"),
+		Difficulty:  p.StringWithFallback("config.difficulty", d.Difficulty),
+		NumTrays:    p.IntWithFallback("config.numTrays", d.NumTrays),
+		Generosity:  p.FloatWithFallback("config.generosity", d.Generosity),
+		BonusChance: p.FloatWithFallback("config.bonusChance", d.BonusChance),
+		MaxItems:    p.IntWithFallback("config.maxItems", d.MaxItems),
+		Seed:        seed,
+
+		TimedMode:       p.Bool("config.timedMode"),
+		PickSeconds:     p.IntWithFallback("config.pickSeconds", d.PickSeconds),
+		DecisionSeconds: p.IntWithFallback("config.decisionSeconds", d.DecisionSeconds),
+		TimeBank:        p.IntWithFallback("config.timeBank", d.TimeBank),
+
+		HostMode:     p.Bool("config.hostMode"),
+		ManualBanker: p.Bool("config.manualBanker"),
+
+		OfferEvery: d.OfferEvery,
+	}
+}
+
+func (c GameConfig) Save(p fyne.Preferences) {
+	p.SetString("config.playerName", c.PlayerName)
+	p.SetString("config.difficulty", c.Difficulty)
+	p.SetInt("config.numTrays", c.NumTrays)
+	p.SetFloat("config.generosity", c.Generosity)
+	p.SetFloat("config.bonusChance", c.BonusChance)
+	p.SetInt("config.maxItems", c.MaxItems)
+	p.SetString("config.seed", strconv.FormatInt(c.Seed, 10))
+	p.SetBool("config.timedMode", c.TimedMode)
+	p.SetInt("config.pickSeconds", c.PickSeconds)
+	p.SetInt("config.decisionSeconds", c.DecisionSeconds)
+	p.SetInt("config.timeBank", c.TimeBank)
+	p.SetBool("config.hostMode", c.HostMode)
+	p.SetBool("config.manualBanker", c.ManualBanker)
+}
+
+// boardValues picks n values from VALUES, always keeping the lowest and
+// highest and spreading the rest evenly
+func boardValues(n int) []int {
+	if n >= len(VALUES) {
+		return append([]int{}, VALUES...)
+	}
+	if n < 2 {
+		n = 2
+	}
+	values := make([]int, n)
+	for i := range values {
+		values[i] = VALUES[(i*(len(VALUES)-1)+(n-1)/2)/(n-1)]
+	}
+	return values
+}
@@ -0,0 +1,324 @@
+package main
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+// Daily challenge dates are plain calendar days
+const dailyLayout = "2006-01-02"
+
+// DailyResult is how the player did in one daily challenge
+type DailyResult struct {
+	Date     string `json:"date"`
+	Player   string `json:"player,omitempty"`
+	Deal     bool   `json:"deal"`
+	Winnings int    `json:"winnings"`
+	Opened   []int  `json:"opened"` // trays opened before the game ended, in order
+}
+
+// dailySeed is the same for everyone on the same day
+func dailySeed(date string) int64 {
+	h := fnv.New64a()
+	h.Write([]byte("mealnomeal-daily-" + date))
+	return int64(h.Sum64() >> 1)
+}
+
+// dailyConfig is the fixed setup of the daily challenge, so every board is
+// the same whatever the player picked on the start screen
+func dailyConfig(date, player string) GameConfig {
+	cfg := DefaultGameConfig()
+	cfg.PlayerName = player
+	cfg.Seed = dailySeed(date)
+	return cfg
+}
+
+func today() string {
+	return time.Now().Format(dailyLayout)
+}
+
+func LoadDailyResults(p fyne.Preferences) []DailyResult {
+	var results []DailyResult
+	if data := p.String("daily.results"); data != "" {
+		if err := json.Unmarshal([]byte(data), &results); err != nil {
+			fyne.LogError("Could not read daily results", err)
+		}
+	}
+	return results
+}
+
+func SaveDailyResults(p fyne.Preferences, results []DailyResult) {
+	data, err := json.Marshal(results)
+	if err != nil {
+		fyne.LogError("Could not save daily results", err)
+		return
+	}
+	p.SetString("daily.results", string(data))
+}
+
+// dailyResult returns the stored result for date, if any
+func dailyResult(p fyne.Preferences, date string) (DailyResult, bool) {
+	for _, r := range LoadDailyResults(p) {
+		if r.Date == date {
+			return r, true
+		}
+	}
+	return DailyResult{}, false
+}
+
+// recordDaily stores the outcome of today's challenge
+func (g *Game) recordDaily(deal bool, winnings int) {
+	p := fyne.CurrentApp().Preferences()
+	SaveDailyResults(p, append(LoadDailyResults(p), DailyResult{
+		Date:     g.daily,
+		Player:   g.config.PlayerName,
+		Deal:     deal,
+		Winnings: winnings,
+		Opened:   append([]int{}, g.openedOrder...),
+	}))
+}
+
+// valueEmoji is one square of the share grid: low values are good to open
+func valueEmoji(v int) string {
+	switch {
+	case v == -1:
+		return "🍔"
+	case v <= 1000:
+		return "🟩"
+	case v <= 50000:
+		return "🟨"
+	default:
+		return "🟥"
+	}
+}
+
+// shareGrid is the opened values as rows of six squares
+func shareGrid(trayValues []int, opened []int) string {
+	var b strings.Builder
+	for i, idx := range opened {
+		if i > 0 && i%6 == 0 {
+			b.WriteString("\n")
+		}
+		b.WriteString(valueEmoji(trayValues[idx]))
+	}
+	return b.String()
+}
+
+// shareCode packs the result with a checksum tied to the day's board
+func shareCode(r DailyResult) string {
+	opened := []string{}
+	for _, idx := range r.Opened {
+		opened = append(opened, strconv.Itoa(idx))
+	}
+	deal := "0"
+	if r.Deal {
+		deal = "1"
+	}
+	player := strings.NewReplacer("|", "", "\n", "").Replace(r.Player)
+	payload := strings.Join([]string{r.Date, player, deal, strconv.Itoa(r.Winnings), strings.Join(opened, ",")}, "|")
+	return base64.RawURLEncoding.EncodeToString([]byte(payload)) + "." + shareChecksum(payload)
+}
+
+// shareChecksum catches typos and casual edits. It is not a signature:
+// anyone can work out the day's seed, so VerifyShare can only tell whether
+// a result is consistent with the board, not that it was really played.
+func shareChecksum(payload string) string {
+	h := fnv.New32a()
+	h.Write([]byte(payload))
+	h.Write([]byte(strconv.FormatInt(dailySeed(strings.SplitN(payload, "|", 2)[0]), 10)))
+	return fmt.Sprintf("%08x", h.Sum32())
+}
+
+// ShareString is what the player pastes into chat
+func ShareString(r DailyResult, trayValues []int) string {
+	outcome := T("daily.share.final")
+	if r.Deal {
+		outcome = T("daily.share.deal")
+	}
+	return fmt.Sprintf("🍽️ Meal or No Meal %s\n%s\n💰 %s %s\n%s",
+		r.Date, shareGrid(trayValues, r.Opened), Money(r.Winnings), outcome, shareCode(r))
+}
+
+// dailyBoard deals the board of a given day
+func dailyBoard(date string) *Game {
+	g := NewGame(dailyConfig(date, ""))
+	g.initialize()
+	return g
+}
+
+// dealRange is the lowest and highest deal the Chef could have offered
+// once the opened trays were gone, bonus round included. ok is false when
+// no offer comes after that many trays.
+func (g *Game) dealRange(opened []int) (low, high int, ok bool) {
+	every := g.config.OfferEvery
+	if every < 1 {
+		every = 3
+	}
+	if len(opened) == 0 || len(opened)%every != 0 && g.numTrays-len(opened) != 2 {
+		return 0, 0, false
+	}
+	remaining := []int{}
+	for i := 0; i < g.numTrays; i++ {
+		if !slices.Contains(opened, i) && g.trayValues[i] != -1 {
+			remaining = append(remaining, g.trayValues[i])
+		}
+	}
+	low, high = g.chef.OfferRange(remaining)
+	q, add := g.bonus.maxQuotient, g.bonus.maxAdditive
+	return max(1, low/q-add), high*q + add, true
+}
+
+// VerifyShare checks a pasted share string is consistent with the board of
+// its day
+func VerifyShare(text string) (DailyResult, error) {
+	lines := strings.Split(strings.TrimSpace(text), "\n")
+	if len(lines) < 4 {
+		return DailyResult{}, errors.New(T("daily.verify.no_code"))
+	}
+	code := strings.TrimSpace(lines[len(lines)-1])
+	parts := strings.Split(code, ".")
+	if len(parts) != 2 {
+		return DailyResult{}, errors.New(T("daily.verify.no_code"))
+	}
+	data, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return DailyResult{}, errors.New(T("daily.verify.no_code"))
+	}
+	payload := string(data)
+	fields := strings.Split(payload, "|")
+	if len(fields) != 5 || shareChecksum(payload) != parts[1] {
+		return DailyResult{}, errors.New(T("daily.verify.tampered"))
+	}
+	if _, err := time.Parse(dailyLayout, fields[0]); err != nil {
+		return DailyResult{}, errors.New(T("daily.verify.tampered"))
+	}
+
+	r := DailyResult{Date: fields[0], Player: fields[1], Deal: fields[2] == "1"}
+	if r.Winnings, err = strconv.Atoi(fields[3]); err != nil {
+		return DailyResult{}, errors.New(T("daily.verify.tampered"))
+	}
+	g := dailyBoard(r.Date)
+	seen := map[int]bool{}
+	if fields[4] != "" {
+		for _, s := range strings.Split(fields[4], ",") {
+			idx, err := strconv.Atoi(s)
+			if err != nil || idx < 0 || idx >= g.numTrays || seen[idx] {
+				return DailyResult{}, errors.New(T("daily.verify.tampered"))
+			}
+			seen[idx] = true
+			r.Opened = append(r.Opened, idx)
+		}
+	}
+
+	// the squares must be the ones this board gives for those trays
+	grid := strings.Join(lines[1:len(lines)-2], "\n")
+	if grid != shareGrid(g.trayValues, r.Opened) {
+		return DailyResult{}, errors.New(T("daily.verify.grid"))
+	}
+	// a deal must be an offer the Chef could have made at that point
+	if r.Deal {
+		low, high, ok := g.dealRange(r.Opened)
+		if !ok || r.Winnings < low || r.Winnings > high {
+			return DailyResult{}, errors.New(T("daily.verify.winnings"))
+		}
+	}
+	// a game played to the end opened all but the last two trays and pays
+	// what one of them held
+	if !r.Deal {
+		if len(r.Opened) != g.numTrays-2 {
+			return DailyResult{}, errors.New(T("daily.verify.winnings"))
+		}
+		found := false
+		for i := 0; i < g.numTrays; i++ {
+			if !seen[i] && g.trayWinnings(i) == r.Winnings {
+				found = true
+			}
+		}
+		if !found {
+			return DailyResult{}, errors.New(T("daily.verify.winnings"))
+		}
+	}
+	return r, nil
+}
+
+// startDaily starts today's challenge; it can only be started once a day
+func startDaily(w fyne.Window) {
+	p := fyne.CurrentApp().Preferences()
+	date := today()
+	clearSavedGame(p)
+	p.SetString("daily.lastStarted", date)
+	g := NewGame(dailyConfig(date, LoadGameConfig(p).PlayerName))
+	g.daily = date
+	g.win = w
+	g.initialize()
+	g.showBoard(fyne.CurrentApp(), nil)
+}
+
+// showDailyDialog offers today's challenge (or today's share string once
+// played) and a box to check a teammate's result
+func showDailyDialog(w fyne.Window) {
+	p := fyne.CurrentApp().Preferences()
+	date := today()
+
+	var d dialog.Dialog
+	var status fyne.CanvasObject
+	if r, ok := dailyResult(p, date); ok {
+		share := widget.NewMultiLineEntry()
+		share.SetText(ShareString(r, dailyBoard(date).trayValues))
+		share.SetMinRowsVisible(6)
+		copyBtn := widget.NewButton(T("daily.copy"), func() {
+			w.Clipboard().SetContent(share.Text)
+		})
+		status = container.NewVBox(widget.NewLabel(T("daily.done", Money(r.Winnings))), share, copyBtn)
+	} else if p.String("daily.lastStarted") == date {
+		status = widget.NewLabel(T("daily.abandoned"))
+	} else {
+		play := widget.NewButton(T("daily.play"), func() {
+			d.Hide()
+			startDaily(w)
+		})
+		play.Importance = widget.HighImportance
+		status = container.NewVBox(widget.NewLabel(T("daily.intro", date)), container.NewCenter(play))
+	}
+
+	paste := widget.NewMultiLineEntry()
+	paste.SetPlaceHolder(T("daily.verify.placeholder"))
+	paste.SetMinRowsVisible(4)
+	verdict := widget.NewLabel("")
+	verdict.Wrapping = fyne.TextWrapWord
+	verify := widget.NewButton(T("daily.verify.button"), func() {
+		r, err := VerifyShare(paste.Text)
+		if err != nil {
+			verdict.SetText("❌ " + err.Error())
+			return
+		}
+		name := r.Player
+		if name == "" {
+			name = "?"
+		}
+		verdict.SetText("✅ " + T("daily.verify.ok", name, r.Date, Money(r.Winnings)))
+	})
+
+	content := container.NewVBox(
+		status,
+		widget.NewSeparator(),
+		widget.NewLabel(T("daily.verify.title")),
+		paste,
+		verify,
+		verdict,
+	)
+	d = dialog.NewCustom(T("daily.title"), T("close"), container.NewGridWrap(fyne.NewSize(420, content.MinSize().Height), content), w)
+	d.Show()
+}
@@ -0,0 +1,44 @@
+package main
+
+import "testing"
+
+func TestVerifyShare(t *testing.T) {
+	const date = "2026-03-14"
+	g := dailyBoard(date)
+	low, high, ok := g.dealRange([]int{0, 1, 2})
+	if !ok || low < 1 || low > high {
+		t.Fatalf("dealRange after 3 trays = %d..%d, %v", low, high, ok)
+	}
+
+	// to the end the player opens every tray but their own and one more
+	all := []int{}
+	for i := 0; i < g.numTrays-2; i++ {
+		all = append(all, i)
+	}
+
+	tests := []struct {
+		name   string
+		result DailyResult
+		ok     bool
+	}{
+		{"lowest deal", DailyResult{Deal: true, Winnings: low, Opened: []int{0, 1, 2}}, true},
+		{"highest deal", DailyResult{Deal: true, Winnings: high, Opened: []int{0, 1, 2}}, true},
+		{"deal above any offer", DailyResult{Deal: true, Winnings: high + 1, Opened: []int{0, 1, 2}}, false},
+		{"deal with no offer due", DailyResult{Deal: true, Winnings: low, Opened: []int{0, 1}}, false},
+		{"deal before any tray", DailyResult{Deal: true, Winnings: low}, false},
+		{"to the end", DailyResult{Winnings: g.trayWinnings(g.numTrays - 1), Opened: all}, true},
+		{"to the end with no such tray", DailyResult{Winnings: 123456789, Opened: all}, false},
+		{"no deal after 3 trays", DailyResult{Winnings: g.trayWinnings(5), Opened: []int{0, 1, 2}}, false},
+		{"no deal, empty grid and the top prize", DailyResult{Winnings: 1000000}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.result.Date = date
+			tt.result.Player = "Ana"
+			_, err := VerifyShare(ShareString(tt.result, g.trayValues))
+			if (err == nil) != tt.ok {
+				t.Errorf("VerifyShare error = %v, want ok %v", err, tt.ok)
+			}
+		})
+	}
+}
@@ -0,0 +1,33 @@
+package main
+
+// Difficulty is a preset that tunes the Chef, the bonuses and the board
+type Difficulty struct {
+	Name           string // "easy", "normal", "hard", "brutal"
+	MinFactor      float64
+	MaxFactor      float64 // Chef offers are average * [MinFactor, MaxFactor)
+	SwapChance     float64 // chance an offer is a swap instead of cash
+	BonusChance    float64 // chance per offer that the bonus round starts
+	MaxQuotient    int     // multiplier cases go up to *MaxQuotient or /MaxQuotient
+	MultiplyChance float64 // share of * multiplier cases
+	MaxAdditive    int     // additive cases go up to ±MaxAdditive
+	PlusChance     float64 // share of + additive cases
+	MaxItems       int     // up to this many food items dilute the board
+}
+
+// Presets in menu order; "normal" is the original game
+var difficulties = []Difficulty{
+	{"easy", 0.80, 1.05, 0.10, 0.40, 3, 0.75, 2000, 0.75, 1},
+	{"normal", 0.60, 0.95, 0.20, 0.30, 5, 0.50, 2000, 0.50, 3},
+	{"hard", 0.45, 0.80, 0.25, 0.25, 6, 0.35, 5000, 0.35, 4},
+	{"brutal", 0.30, 0.65, 0.30, 0.20, 8, 0.20, 10000, 0.20, 5},
+}
+
+// difficultyByName returns the preset with that name, or "normal"
+func difficultyByName(name string) Difficulty {
+	for _, d := range difficulties {
+		if d.Name == name {
+			return d
+		}
+	}
+	return difficulties[1]
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+// lastOtherTray returns the only unopened tray besides the player's, or -1
+func (g *Game) lastOtherTray() int {
+	for i := 0; i < g.numTrays; i++ {
+		if i != g.playerTray && !g.gridButtons[i].Disabled() {
+			return i
+		}
+	}
+	return -1
+}
+
+// trayWinnings is what a tray pays out; food items are worth nothing
+func (g *Game) trayWinnings(idx int) int {
+	if g.trayValues[idx] == -1 {
+		return 0
+	}
+	return g.trayValues[idx]
+}
+
+// Final phase: after the last offer is declined the player may keep their
+// tray or swap it with the last one on the board
+func (g *Game) showFinalDecision(parent fyne.Window) {
+	other := g.lastOtherTray()
+	if other == -1 {
+		g.showFinalReveal(parent, -1, false)
+		return
+	}
+
+	keepBtn := widget.NewButton(T("final.keep", g.pack.TrayIcon, g.playerTray+1), nil)
+	swapBtn := widget.NewButton(T("final.swap", other+1), nil)
+	keepBtn.Importance = widget.HighImportance
+	swapBtn.Importance = widget.MediumImportance
+
+	content := container.NewVBox(
+		widget.NewLabel(T("final.question", g.playerTray+1, other+1)),
+		container.NewHBox(touchButton(keepBtn), touchButton(swapBtn)),
+	)
+	dlg := dialog.NewCustomWithoutButtons(T("final.title"), content, parent)
+
+	keepBtn.OnTapped = func() {
+		g.clock.Stop()
+		dlg.Hide()
+		g.showFinalReveal(parent, other, false)
+	}
+	swapBtn.OnTapped = func() {
+		g.clock.Stop()
+		dlg.Hide()
+		g.swapPlayerTray(other)
+		// the tray the player gave up is now the "other" one
+		g.showFinalReveal(parent, g.lastOtherTray(), true)
+	}
+
+	dlg.Show()
+	g.startDecisionClock(keepBtn.OnTapped)
+}
+
+// Build the picture and caption for a tray's contents
+func (g *Game) trayRevealView(idx int, title string) fyne.CanvasObject {
+	if g.itemNames[idx] != "" {
+		foodImg := loadImage(g.itemImages[idx], 200, 200)
+		return container.NewVBox(
+			widget.NewLabel(T("reveal.contains", title, idx+1, g.itemNames[idx])),
+			container.NewCenter(foodImg),
+		)
+	}
+
+	label := widget.NewLabel(T("reveal.contains", title, idx+1, Money(g.trayValues[idx])))
+	moneyImg := loadImage(valueAsset(g.trayValues[idx]), 200, 200)
+	return container.NewVBox(label, container.NewCenter(moneyImg))
+}
+
+// Reveal the player's tray and the last tray side by side and record the result
+func (g *Game) showFinalReveal(parent fyne.Window, other int, swapped bool) {
+	var contentWidget fyne.CanvasObject
+	won := g.trayWinnings(g.playerTray)
+
+	if other == -1 {
+		contentWidget = g.trayRevealView(g.playerTray, g.pack.TrayIcon+" "+T("reveal.your_tray"))
+		updateStats(func(s *Stats) { s.RecordFinal(swapped, won, 0) })
+		g.recordResult(false, won)
+	} else {
+		left := g.trayWinnings(other)
+		verdict := T("reveal.good")
+		if left > won {
+			verdict = T("reveal.bad")
+		}
+		contentWidget = container.NewVBox(
+			container.NewHBox(
+				g.trayRevealView(g.playerTray, g.pack.TrayIcon+" "+T("reveal.your_tray")),
+				widget.NewSeparator(),
+				g.trayRevealView(other, T("reveal.other_tray")),
+			),
+			widget.NewSeparator(),
+			container.NewCenter(widget.NewLabel(verdict)),
+		)
+		updateStats(func(s *Stats) { s.RecordFinal(swapped, won, left) })
+		g.recordResult(false, won)
+	}
+
+	g.achieve(GameEvent{Kind: EventFinal, Amount: won, Swapped: swapped})
+
+	d := dialog.NewCustom(T("reveal.title"), T("ok"), contentWidget, parent)
+	d.SetOnClosed(func() {
+		g.lockBoard()
+		g.showPlayAgain(parent)
+	})
+	showCountdown(parent, 3, func() {
+		g.sound.Play(CueFinalReveal)
+		d.Show()
+	})
+}
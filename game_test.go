@@ -0,0 +1,546 @@
+package main
+
+import (
+	"math/rand"
+	"slices"
+	"strings"
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/test"
+	"fyne.io/fyne/v2/widget"
+)
+
+// Scenario tests drive a whole Game through the Fyne test driver: trays are
+// tapped on the board and dialogs are answered by tapping their buttons.
+
+// newTestGame starts a board with a fixed seed and no animations
+func newTestGame(t *testing.T, cfg GameConfig) (*Game, fyne.Window) {
+	t.Helper()
+	test.NewTempApp(t)
+	updateSettings(func(s *Settings) { s.ReduceMotion = true })
+	w := test.NewWindow(nil)
+	w.Resize(fyne.NewSize(1000, 600))
+	t.Cleanup(w.Close)
+	if cfg.Seed == 0 {
+		cfg.Seed = 42
+	}
+	return startGame(w, cfg), w
+}
+
+// testConfig is the default setup without bonuses, so the flows are predictable
+func testConfig() GameConfig {
+	cfg := DefaultGameConfig()
+	cfg.BonusChance = 0
+	return cfg
+}
+
+// overlayObjects lists everything shown in dialogs and pop-ups, topmost first
+func overlayObjects(w fyne.Window) []fyne.CanvasObject {
+	overlays := w.Canvas().Overlays().List()
+	objects := []fyne.CanvasObject{}
+	for i := len(overlays) - 1; i >= 0; i-- {
+		objects = append(objects, test.LaidOutObjects(overlays[i])...)
+	}
+	return objects
+}
+
+// findDialogButton finds a visible button whose text starts with prefix
+func findDialogButton(w fyne.Window, prefix string) *widget.Button {
+	for _, o := range overlayObjects(w) {
+		if b, ok := o.(*widget.Button); ok && b.Visible() && strings.HasPrefix(b.Text, prefix) {
+			return b
+		}
+	}
+	return nil
+}
+
+// tapDialog taps a dialog button and fails the test if there is none
+func tapDialog(t *testing.T, w fyne.Window, prefix string) {
+	t.Helper()
+	b := findDialogButton(w, prefix)
+	if b == nil {
+		t.Fatalf("no dialog button %q", prefix)
+	}
+	test.Tap(b)
+}
+
+// dialogHasText tells whether any label in the dialogs contains text
+func dialogHasText(w fyne.Window, text string) bool {
+	for _, o := range overlayObjects(w) {
+		if l, ok := o.(*widget.Label); ok && strings.Contains(l.Text, text) {
+			return true
+		}
+	}
+	return false
+}
+
+// pickTray picks the player's tray and closes the "your tray" dialog
+func pickTray(t *testing.T, g *Game, w fyne.Window, idx int) {
+	t.Helper()
+	test.Tap(g.gridButtons[idx])
+	tapDialog(t, w, T("ok"))
+}
+
+// openTray opens a tray and closes the dialog showing what was inside
+func openTray(t *testing.T, g *Game, w fyne.Window, idx int) {
+	t.Helper()
+	test.Tap(g.gridButtons[idx])
+	tapDialog(t, w, T("ok"))
+}
+
+// openTrays opens the next n unopened trays that are not the player's
+func openTrays(t *testing.T, g *Game, w fyne.Window, n int) {
+	t.Helper()
+	for i := 0; i < g.numTrays && n > 0; i++ {
+		if i != g.playerTray && !g.gridButtons[i].Disabled() {
+			openTray(t, g, w, i)
+			n--
+		}
+	}
+}
+
+func TestPickPlayerTray(t *testing.T) {
+	g, w := newTestGame(t, testConfig())
+
+	test.Tap(g.gridButtons[4])
+	if g.playerTray != 4 {
+		t.Fatalf("playerTray = %d, want 4", g.playerTray)
+	}
+	if !g.gridButtons[4].Disabled() {
+		t.Error("the player's tray should be disabled on the board")
+	}
+	if !dialogHasText(w, T("tray.yours.body", 5)) {
+		t.Error("no dialog telling the player which tray is theirs")
+	}
+	tapDialog(t, w, T("ok"))
+
+	// the player's own tray cannot be opened
+	g.onTrayClicked(fyne.CurrentApp(), 4)
+	if g.openedTraysCount != 0 {
+		t.Error("opening the player's tray should be refused")
+	}
+}
+
+func TestOpenTraysMarksSidebar(t *testing.T) {
+	g, w := newTestGame(t, testConfig())
+	pickTray(t, g, w, 0)
+
+	openTray(t, g, w, 1)
+	if g.openedTraysCount != 1 || !g.gridButtons[1].Disabled() {
+		t.Fatalf("tray 2 not opened: count %d", g.openedTraysCount)
+	}
+	val := g.trayValues[1]
+	if g.trayReplaced[1] != -1 {
+		val = g.trayReplaced[1]
+	}
+	if !g.isValueOpened(val) {
+		t.Errorf("value %d not marked as opened", val)
+	}
+	if got := g.getUnopenedCount(); got != g.numTrays-2 {
+		t.Errorf("getUnopenedCount() = %d, want %d", got, g.numTrays-2)
+	}
+}
+
+func TestDeclineOffer(t *testing.T) {
+	g, w := newTestGame(t, testConfig())
+	g.chef.swapChance = 0
+	pickTray(t, g, w, 0)
+	openTrays(t, g, w, 3)
+
+	if len(g.offers) != 1 || g.offers[0].Kind != CashOffer {
+		t.Fatalf("want one cash offer after 3 trays, got %+v", g.offers)
+	}
+	tapDialog(t, w, T("decline"))
+	if g.offers[0].Response != ResponseNoDeal {
+		t.Errorf("response = %q, want %q", g.offers[0].Response, ResponseNoDeal)
+	}
+
+	// the game goes on: the next offer comes after 3 more trays
+	openTrays(t, g, w, 2)
+	if len(g.offers) != 1 {
+		t.Errorf("offer came too early: %d offers", len(g.offers))
+	}
+}
+
+func TestAcceptDeal(t *testing.T) {
+	g, w := newTestGame(t, testConfig())
+	g.chef.swapChance = 0
+	pickTray(t, g, w, 0)
+	openTrays(t, g, w, 3)
+	offer := g.offers[0].Amount
+
+	tapDialog(t, w, T("accept"))
+	if !dialogHasText(w, T("deal.accepted", Money(offer))) {
+		t.Fatal("no deal accepted dialog")
+	}
+	tapDialog(t, w, T("ok"))
+
+	for i, b := range g.gridButtons {
+		if !b.Disabled() {
+			t.Errorf("tray %d still enabled after the deal", i+1)
+		}
+	}
+	stats := LoadStats(fyne.CurrentApp().Preferences())
+	if stats.DealsTaken != 1 || stats.TotalWinnings != offer {
+		t.Errorf("stats = %+v, want one deal of %d", stats, offer)
+	}
+	results := LoadResults(fyne.CurrentApp().Preferences())
+	if len(results) != 1 || !results[0].Deal || results[0].Winnings != offer {
+		t.Errorf("results = %+v", results)
+	}
+	if findDialogButton(w, T("game_over.setup")) == nil {
+		t.Error("no Game Over dialog")
+	}
+}
+
+func TestSwapOffer(t *testing.T) {
+	g, w := newTestGame(t, testConfig())
+	g.chef.swapChance = 1
+	pickTray(t, g, w, 0)
+	openTrays(t, g, w, 3)
+	mine, theirs := g.trayValues[0], g.trayValues[10]
+
+	tapDialog(t, w, T("accept"))
+	var sel *widget.Select
+	for _, o := range overlayObjects(w) {
+		if s, ok := o.(*widget.Select); ok {
+			sel = s
+		}
+	}
+	if sel == nil {
+		t.Fatal("no tray picker in the swap dialog")
+	}
+	sel.SetSelected("11")
+	tapDialog(t, w, T("swap.button"))
+	tapDialog(t, w, T("ok"))
+
+	if g.playerTray != 10 {
+		t.Fatalf("playerTray = %d, want 10", g.playerTray)
+	}
+	if g.trayValues[g.playerTray] != theirs || g.trayValues[0] != mine {
+		t.Error("the player should now hold the other tray's contents")
+	}
+	if g.gridButtons[0].Disabled() || !g.gridButtons[10].Disabled() {
+		t.Error("old tray should be back on the board and the new one taken off")
+	}
+	if g.offers[0].Response != ResponseSwapped {
+		t.Errorf("response = %q, want %q", g.offers[0].Response, ResponseSwapped)
+	}
+}
+
+func TestBonusChoice(t *testing.T) {
+	cfg := testConfig()
+	cfg.BonusChance = 1
+	g, w := newTestGame(t, cfg)
+	g.chef.swapChance = 0
+	g.bonus.multiplierActive = true
+	g.bonus.additiveActive = false
+	pickTray(t, g, w, 0)
+	openTrays(t, g, w, 3)
+
+	tapDialog(t, w, T("bonus.case", 1))
+	if g.bonus.HasMultiplier() || g.bonus.multiplierCase == "" {
+		t.Fatal("multiplier was not chosen")
+	}
+	chosen := g.bonus.multiplierCase
+	tapDialog(t, w, "OK")
+
+	// the bonus is applied to the offer that follows
+	if !dialogHasText(w, T("bonus.applied")) {
+		t.Fatal("no bonus applied dialog")
+	}
+	if g.offers[0].Multiplier != chosen {
+		t.Errorf("offer multiplier = %q, want %q", g.offers[0].Multiplier, chosen)
+	}
+	if g.bonus.HasPendingBonus() {
+		t.Error("bonus should be used up by the offer")
+	}
+	tapDialog(t, w, T("continue"))
+	tapDialog(t, w, T("decline"))
+	if g.offers[0].Response != ResponseNoDeal {
+		t.Errorf("response = %q, want %q", g.offers[0].Response, ResponseNoDeal)
+	}
+}
+
+// playToFinal declines every offer until the keep-or-swap question
+func playToFinal(t *testing.T, g *Game, w fyne.Window) {
+	t.Helper()
+	for g.getUnopenedCount() > 1 {
+		openTrays(t, g, w, 1)
+		if findDialogButton(w, T("decline")) != nil {
+			tapDialog(t, w, T("decline"))
+		}
+	}
+}
+
+func TestFinalReveal(t *testing.T) {
+	for _, swap := range []bool{false, true} {
+		cfg := testConfig()
+		cfg.NumTrays = 6
+		g, w := newTestGame(t, cfg)
+		g.chef.swapChance = 0
+		pickTray(t, g, w, 0)
+		playToFinal(t, g, w)
+
+		other := g.lastOtherTray()
+		mine, theirs := g.trayWinnings(0), g.trayWinnings(other)
+		if swap {
+			tapDialog(t, w, T("final.swap", other+1))
+		} else {
+			tapDialog(t, w, T("final.keep", g.pack.TrayIcon, 1))
+		}
+		tapDialog(t, w, T("ok"))
+
+		want := mine
+		if swap {
+			want = theirs
+		}
+		stats := LoadStats(fyne.CurrentApp().Preferences())
+		if stats.GamesPlayed != 1 || stats.TotalWinnings != want {
+			t.Errorf("swap=%v: stats = %+v, want winnings %d", swap, stats, want)
+		}
+		if swap && stats.FinalSwaps != 1 || !swap && stats.FinalKeeps != 1 {
+			t.Errorf("swap=%v: final choice not counted: %+v", swap, stats)
+		}
+		if findDialogButton(w, T("game_over.setup")) == nil {
+			t.Errorf("swap=%v: no Game Over dialog", swap)
+		}
+	}
+}
+
+// The offer paths below put offers on the table directly, so each kind is
+// covered whatever the Chef would have picked.
+
+func TestCashOffer(t *testing.T) {
+	g, w := newTestGame(t, testConfig())
+	pickTray(t, g, w, 0)
+
+	g.presentOffer(w, Offer{Kind: CashOffer, Base: 500, Amount: 500})
+	if !dialogHasText(w, T("offer.body", Money(500))) {
+		t.Fatal("no cash offer dialog")
+	}
+	tapDialog(t, w, T("decline"))
+	if findDialogButton(w, T("accept")) != nil {
+		t.Error("offer dialog still open after declining")
+	}
+
+	g.presentOffer(w, Offer{Kind: CashOffer, Base: 700, Amount: 700})
+	tapDialog(t, w, T("accept"))
+	if !dialogHasText(w, T("deal.accepted", Money(700))) {
+		t.Error("no deal accepted dialog")
+	}
+}
+
+func TestBonusAppliedOffer(t *testing.T) {
+	g, w := newTestGame(t, testConfig())
+	pickTray(t, g, w, 0)
+
+	// a seed whose first draw is no swap
+	g.chef.r = rand.New(rand.NewSource(1))
+	g.bonus.multiplier = 2
+	o := g.buildOffer([]int{100, 300})
+	if o.Kind != CashOffer || o.BonusDesc == "" || o.Amount != o.Base*2 {
+		t.Fatalf("buildOffer with a x2 bonus = %+v", o)
+	}
+	if g.bonus.HasPendingBonus() {
+		t.Error("bonus should be used up by the offer")
+	}
+
+	g.presentOffer(w, o)
+	if !dialogHasText(w, T("bonus.applied")) {
+		t.Fatal("no bonus applied dialog")
+	}
+	tapDialog(t, w, T("continue"))
+	if findDialogButton(w, T("decline")) == nil {
+		t.Error("the bonus applied dialog should lead to the offer")
+	}
+}
+
+func TestSwapOfferAfterBonus(t *testing.T) {
+	g, w := newTestGame(t, testConfig())
+	pickTray(t, g, w, 0)
+	mine, theirs := g.trayValues[0], g.trayValues[10]
+
+	// the bonus round is followed by a swap offer, which used to stall
+	g.bonus.multiplierActive, g.bonus.additiveActive = true, false
+	g.showBonusSequence(w, func() {
+		g.presentOffer(w, Offer{Kind: SwapOffer})
+	})
+	tapDialog(t, w, T("bonus.case", 1))
+	tapDialog(t, w, T("ok"))
+	tapDialog(t, w, T("accept"))
+
+	var sel *widget.Select
+	for _, o := range overlayObjects(w) {
+		if s, ok := o.(*widget.Select); ok {
+			sel = s
+		}
+	}
+	if sel == nil {
+		t.Fatal("no tray picker in the swap dialog")
+	}
+	sel.SetSelected("11")
+	tapDialog(t, w, T("swap.button"))
+	tapDialog(t, w, T("ok"))
+
+	if g.playerTray != 10 {
+		t.Fatalf("playerTray = %d, want 10", g.playerTray)
+	}
+	if g.trayValues[10] != theirs || g.trayValues[0] != mine {
+		t.Error("the player should now hold the other tray's contents")
+	}
+	if g.gridButtons[0].Disabled() || !g.gridButtons[10].Disabled() {
+		t.Error("old tray should be back on the board and the new one taken off")
+	}
+}
+
+func TestDeclineSwapOffer(t *testing.T) {
+	g, w := newTestGame(t, testConfig())
+	pickTray(t, g, w, 0)
+
+	g.presentOffer(w, Offer{Kind: SwapOffer})
+	tapDialog(t, w, T("decline"))
+	if g.playerTray != 0 || len(w.Canvas().Overlays().List()) != 0 {
+		t.Error("declining a swap should leave the player's tray alone")
+	}
+}
+
+func TestFinalOffer(t *testing.T) {
+	for _, swap := range []bool{false, true} {
+		g, w := newTestGame(t, testConfig())
+		pickTray(t, g, w, 0)
+		// open the rest of the board behind the scenes, with no offers
+		for i := 2; i < g.numTrays; i++ {
+			g.gridButtons[i].Disable()
+			g.openedTraysCount++
+			g.openedOrder = append(g.openedOrder, i)
+			g.openedValues[g.sidebarValue(i)] = true
+		}
+		g.changed()
+		if g.getUnopenedCount() != 1 {
+			t.Fatalf("getUnopenedCount() = %d, want 1", g.getUnopenedCount())
+		}
+		mine, theirs := g.trayWinnings(0), g.trayWinnings(1)
+
+		// turning the final offer down goes on to keep or swap
+		g.presentOffer(w, Offer{Kind: CashOffer, Base: 100, Amount: 100, Final: true})
+		tapDialog(t, w, T("decline"))
+		if swap {
+			tapDialog(t, w, T("final.swap", 2))
+		} else {
+			tapDialog(t, w, T("final.keep", g.pack.TrayIcon, 1))
+		}
+		if !dialogHasText(w, T("reveal.other_tray")) {
+			t.Fatalf("swap=%v: no dual reveal", swap)
+		}
+		tapDialog(t, w, T("ok"))
+
+		want := mine
+		if swap {
+			want = theirs
+		}
+		stats := LoadStats(fyne.CurrentApp().Preferences())
+		if stats.GamesPlayed != 1 || stats.TotalWinnings != want {
+			t.Errorf("swap=%v: stats = %+v, want winnings %d", swap, stats, want)
+		}
+		if swap && stats.FinalSwaps != 1 || !swap && stats.FinalKeeps != 1 {
+			t.Errorf("swap=%v: final choice not counted: %+v", swap, stats)
+		}
+	}
+}
+
+func TestSidebarWaitsForReveal(t *testing.T) {
+	g, w := newTestGame(t, testConfig())
+	pickTray(t, g, w, 0)
+	i := slices.Index(g.values, g.sidebarValue(1))
+
+	// the sidebar hears of every change before the watchers added after it
+	var struck []bool
+	g.watch(func() {
+		s, _ := g.sidebar[i].Get()
+		struck = append(struck, ValueStatus(s)&ValueOpened != 0)
+	})
+	test.Tap(g.gridButtons[1])
+	if len(struck) < 2 || struck[0] || !struck[len(struck)-1] {
+		t.Errorf("sidebar struck the value as %v, want only once the reveal is done", struck)
+	}
+	if !g.openedValues[g.sidebarValue(1)] {
+		t.Error("the model does not have the value opened during the reveal")
+	}
+	tapDialog(t, w, T("ok"))
+	if s, _ := g.sidebar[i].Get(); ValueStatus(s)&ValueOpened == 0 {
+		t.Error("value not struck after the reveal")
+	}
+}
+
+func TestSidebarFollowsModel(t *testing.T) {
+	cfg := testConfig()
+	cfg.MaxItems = 5
+	g, w := newTestGame(t, cfg)
+	pickTray(t, g, w, 0)
+
+	cellFor := func(v int) *valueCell {
+		for i, bv := range g.values {
+			if bv == v {
+				return g.sidebarCells[i]
+			}
+		}
+		t.Fatalf("value %d not on the board", v)
+		return nil
+	}
+
+	// food trays mask the value they replaced
+	for i := range g.trayReplaced {
+		if v := g.trayReplaced[i]; v != -1 && cellFor(v).label.Text != g.pack.ItemLabel {
+			t.Errorf("value %d shows %q, want the item label", v, cellFor(v).label.Text)
+		}
+	}
+
+	openTray(t, g, w, 1)
+	cell := cellFor(g.sidebarValue(1))
+	if cell.drawn != 1 || cell.strike.Hidden || cell.label.Importance != widget.LowImportance {
+		t.Error("opened value is not struck through")
+	}
+	if strings.HasPrefix(cell.label.Text, "✓") {
+		t.Error("opened value still uses a text prefix")
+	}
+
+	// a swap moves the player, not the values
+	before := []int{}
+	for _, b := range g.sidebar {
+		s, _ := b.Get()
+		before = append(before, s)
+	}
+	g.swapPlayerTray(2)
+	for i, b := range g.sidebar {
+		if s, _ := b.Get(); s != before[i] {
+			t.Errorf("value %d changed status after a swap", g.values[i])
+		}
+	}
+	if err := g.Invariants(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestOwnTrayKeepsPickClock(t *testing.T) {
+	cfg := testConfig()
+	cfg.TimedMode = true
+	g, w := newTestGame(t, cfg)
+	pickTray(t, g, w, 0)
+	if !g.clock.running {
+		t.Fatal("the pick clock should run after the player's tray is picked")
+	}
+
+	// tapping your own tray is refused and the clock keeps going
+	g.onTrayClicked(fyne.CurrentApp(), 0)
+	tapDialog(t, w, T("ok"))
+	if !g.clock.running {
+		t.Error("the pick clock stopped after tapping the player's own tray")
+	}
+
+	openTray(t, g, w, 1)
+	if !g.clock.running {
+		t.Error("the pick clock should run again after a tray is opened")
+	}
+}
@@ -0,0 +1,219 @@
+package main
+
+import (
+	"image/color"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// How the player answered an offer
+const (
+	ResponsePending   = ""
+	ResponseDeal      = "deal"
+	ResponseNoDeal    = "no_deal"
+	ResponseSwapped   = "swapped"
+	ResponseKept      = "kept"
+	ResponseWouldHave = "would_have" // offer made during a play-out after the deal
+)
+
+// OfferRecord is one Chef offer as shown in the history panel
+type OfferRecord struct {
+	Round      int
+	Kind       OfferKind
+	Base       int    // cash offer before any bonus
+	Amount     int    // cash offer after any bonus, 0 for swaps
+	Bonus      string // what the bonus did to the offer, "" if nothing
+	EV         int    // average of the money still in play, see moneyAverage
+	MaxLeft    int
+	Response   string
+	Multiplier string // multiplier case applied to the offer, like "/5"
+	Opened     int    // trays opened when the offer was made
+	By         string `json:",omitempty"` // who made the offer, see Offer.By
+}
+
+var (
+	chartOfferColor = color.NRGBA{R: 30, G: 136, B: 229, A: 255}
+	chartEVColor    = color.NRGBA{R: 67, G: 160, B: 71, A: 255}
+	chartMaxColor   = color.NRGBA{R: 251, G: 140, B: 0, A: 255}
+)
+
+// Size of the chart in the side panel and on the Game Over dialog
+var chartSize = fyne.NewSize(260, 140)
+
+// expectedValue is the average of the money still in play, the player's
+// tray included, and the highest amount left. Food is left out the same
+// way the Chef leaves it out of his offers.
+func (g *Game) expectedValue() (ev, max int) {
+	values := g.remainingValues()
+	for _, v := range values {
+		if v > max {
+			max = v
+		}
+	}
+	avg, _ := moneyAverage(values)
+	return int(avg), max
+}
+
+// recordOffer adds an offer to the history before it is shown
+func (g *Game) recordOffer(o Offer) {
+	ev, max := g.expectedValue()
+	rec := OfferRecord{Round: len(g.offers) + 1, Kind: o.Kind, EV: ev, MaxLeft: max, Opened: g.openedTraysCount, By: o.By}
+	if o.Kind == CashOffer {
+		rec.Base = o.Base
+		rec.Amount = o.Amount
+		rec.Bonus = o.BonusDesc
+		rec.Multiplier = o.Multiplier
+	}
+	g.offers = append(g.offers, rec)
+	g.refreshHistory()
+	g.verify("an offer")
+	g.changed()
+}
+
+// respond stores the player's answer to the latest offer
+func (g *Game) respond(response string) {
+	if len(g.offers) == 0 {
+		return
+	}
+	g.offers[len(g.offers)-1].Response = response
+	g.refreshHistory()
+	g.changed()
+}
+
+// historyRows is one label per offer, latest first
+func (g *Game) historyRows() []fyne.CanvasObject {
+	if len(g.offers) == 0 {
+		return []fyne.CanvasObject{widget.NewLabel(T("history.empty"))}
+	}
+	rows := []fyne.CanvasObject{}
+	for i := len(g.offers) - 1; i >= 0; i-- {
+		o := g.offers[i]
+		offer := T("history.swap")
+		if o.Kind == CashOffer {
+			offer = Money(o.Amount)
+		}
+		response := "…"
+		if o.Response != ResponsePending {
+			response = T("history." + o.Response)
+		}
+		rows = append(rows, widget.NewLabel(T("history.row", o.Round, offer, Money(o.EV), response)))
+	}
+	return rows
+}
+
+// historyPanel is the live list of offers and the chart next to the board
+func (g *Game) historyPanel() fyne.CanvasObject {
+	g.history = container.NewStack()
+	g.refreshHistory()
+	return g.history
+}
+
+// refreshHistory redraws the panel after an offer or a language change
+func (g *Game) refreshHistory() {
+	if g.history == nil {
+		return
+	}
+	g.history.Objects = []fyne.CanvasObject{container.NewBorder(
+		container.NewVBox(
+			widget.NewLabelWithStyle(T("history.title"), fyne.TextAlignCenter, fyne.TextStyle{Bold: true}),
+			offerChart(g.offers, chartSize),
+			chartLegend(),
+		),
+		nil, nil, nil,
+		container.NewVScroll(container.NewVBox(g.historyRows()...)),
+	)}
+	g.history.Refresh()
+}
+
+// offerSummary is the chart and list shown at the end of the game
+func (g *Game) offerSummary() fyne.CanvasObject {
+	if len(g.offers) == 0 {
+		return widget.NewLabel(T("history.empty"))
+	}
+	return container.NewVBox(
+		container.NewCenter(offerChart(g.offers, chartSize)),
+		container.NewCenter(chartLegend()),
+		container.NewVBox(g.historyRows()...),
+	)
+}
+
+// chartLegend names the three lines of the chart
+func chartLegend() fyne.CanvasObject {
+	item := func(c color.Color, key string) fyne.CanvasObject {
+		swatch := canvas.NewRectangle(c)
+		swatch.SetMinSize(fyne.NewSize(12, 12))
+		return container.NewHBox(container.NewCenter(swatch), widget.NewLabel(T(key)))
+	}
+	return container.NewHBox(
+		item(chartOfferColor, "history.legend.offer"),
+		item(chartEVColor, "history.legend.ev"),
+		item(chartMaxColor, "history.legend.max"),
+	)
+}
+
+// offerChart draws offers, EV and the highest amount left per round as
+// three line series. Swap offers have no cash amount and leave a gap in
+// the offer line.
+func offerChart(records []OfferRecord, size fyne.Size) fyne.CanvasObject {
+	bg := canvas.NewRectangle(theme.Color(theme.ColorNameInputBackground))
+	bg.Resize(size)
+	objects := []fyne.CanvasObject{bg}
+
+	top := 1
+	for _, r := range records {
+		for _, v := range []int{r.Amount, r.EV, r.MaxLeft} {
+			if v > top {
+				top = v
+			}
+		}
+	}
+
+	pad := float32(8)
+	w, h := size.Width-2*pad, size.Height-2*pad
+	point := func(i, v int) fyne.Position {
+		x := pad + w/2
+		if len(records) > 1 {
+			x = pad + w*float32(i)/float32(len(records)-1)
+		}
+		return fyne.NewPos(x, pad+h*(1-float32(v)/float32(top)))
+	}
+
+	axis := canvas.NewLine(theme.Color(theme.ColorNameDisabled))
+	axis.Position1 = fyne.NewPos(pad, pad+h)
+	axis.Position2 = fyne.NewPos(pad+w, pad+h)
+	objects = append(objects, axis)
+
+	series := func(c color.Color, value func(r OfferRecord) (int, bool)) {
+		prev := -1
+		for i, r := range records {
+			v, ok := value(r)
+			if !ok {
+				prev = -1
+				continue
+			}
+			p := point(i, v)
+			if prev >= 0 {
+				pv, _ := value(records[prev])
+				line := canvas.NewLine(c)
+				line.StrokeWidth = 2
+				line.Position1 = point(prev, pv)
+				line.Position2 = p
+				objects = append(objects, line)
+			}
+			dot := canvas.NewCircle(c)
+			dot.Move(p.SubtractXY(3, 3))
+			dot.Resize(fyne.NewSize(6, 6))
+			objects = append(objects, dot)
+			prev = i
+		}
+	}
+	series(chartMaxColor, func(r OfferRecord) (int, bool) { return r.MaxLeft, true })
+	series(chartEVColor, func(r OfferRecord) (int, bool) { return r.EV, true })
+	series(chartOfferColor, func(r OfferRecord) (int, bool) { return r.Amount, r.Kind == CashOffer })
+
+	return container.NewGridWrap(size, container.NewWithoutLayout(objects...))
+}
@@ -0,0 +1,68 @@
+package main
+
+import "testing"
+
+func TestExpectedValue(t *testing.T) {
+	tests := []struct {
+		name    string
+		values  []int // -1 is food
+		opened  []int
+		ev, max int
+	}{
+		{"all money", []int{1, 5, 10, 100, 1000, 10000}, nil, 1852, 10000},
+		{"food is left out", []int{-1, 100, 300, -1, -1, -1}, nil, 200, 300},
+		{"opened trays are left out", []int{1, 5, 10, 100, 1000, 10000}, []int{1, 5}, 277, 1000},
+		{"the player's tray counts", []int{1000, 5, 10, 100, 1, 10000}, []int{1, 2, 3, 4}, 5500, 10000},
+		{"only food", []int{-1, -1, -1, -1, -1, -1}, nil, 0, 0},
+	}
+	cfg := testConfig()
+	cfg.NumTrays = 6
+	for _, tt := range tests {
+		g, _ := newTestGame(t, cfg)
+		g.playerTray = 0
+		g.trayValues = tt.values
+		for _, i := range tt.opened {
+			g.gridButtons[i].Disable()
+		}
+		ev, max := g.expectedValue()
+		if ev != tt.ev || max != tt.max {
+			t.Errorf("%s: expectedValue() = %d, %d, want %d, %d", tt.name, ev, max, tt.ev, tt.max)
+		}
+		// a Chef who offers the average offers the EV
+		c := NewChef(1)
+		c.minFactor, c.maxFactor = 1, 1
+		if got := c.CalculateOffer(g.remainingValues()); got != tt.ev {
+			t.Errorf("%s: the Chef's average is %d, the EV %d", tt.name, got, tt.ev)
+		}
+	}
+}
+
+func TestRecordOfferAndRespond(t *testing.T) {
+	g, w := newTestGame(t, testConfig())
+	g.respond(ResponseDeal) // nothing on the table yet
+	g.chef.swapChance = 0
+	pickTray(t, g, w, 0)
+	openTrays(t, g, w, 3)
+
+	ev, max := g.expectedValue()
+	if len(g.offers) != 1 {
+		t.Fatalf("offers = %+v, want one", g.offers)
+	}
+	o := g.offers[0]
+	if o.Round != 1 || o.Kind != CashOffer || o.Amount <= 0 || o.EV != ev || o.MaxLeft != max || o.Opened != 3 || o.Response != ResponsePending {
+		t.Errorf("recorded offer %+v, EV %d, highest %d", o, ev, max)
+	}
+	tapDialog(t, w, T("decline"))
+	if g.offers[0].Response != ResponseNoDeal {
+		t.Errorf("response = %q, want %q", g.offers[0].Response, ResponseNoDeal)
+	}
+
+	g.recordOffer(Offer{Kind: SwapOffer, Amount: 500})
+	g.respond(ResponseKept)
+	if len(g.offers) != 2 {
+		t.Fatalf("offers = %+v, want two", g.offers)
+	}
+	if o := g.offers[1]; o.Round != 2 || o.Amount != 0 || o.Response != ResponseKept || g.offers[0].Response != ResponseNoDeal {
+		t.Errorf("offers = %+v, want the swap kept as round 2", g.offers)
+	}
+}
@@ -0,0 +1,151 @@
+package main
+
+import (
+	"slices"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+// Host mode for live events: the game window is the audience display and a
+// second window is the host's console. The console shows what is in every
+// tray, the EV and the range the Chef's offer will fall in, and lets the
+// host call an offer or a bonus round whenever the show needs one.
+
+// hostWindow is the open host console, nil if there is none
+var hostWindow fyne.Window
+
+// showHostConsole opens the host console for g, or moves the open one over
+// to g when the next game starts
+func (g *Game) showHostConsole() {
+	if hostWindow == nil {
+		hostWindow = fyne.CurrentApp().NewWindow(T("host.title"))
+		hostWindow.SetOnClosed(func() { hostWindow = nil })
+		hostWindow.Resize(fyne.NewSize(720, 520))
+		hostWindow.Show()
+	}
+
+	status := widget.NewLabel("")
+	trays := container.NewGridWithColumns(4)
+	cash := widget.NewButton(T("host.cash_offer"), func() { g.hostOffer(CashOffer) })
+	swap := widget.NewButton(T("host.swap_offer"), func() { g.hostOffer(SwapOffer) })
+	bonus := widget.NewButton(T("host.bonus"), g.hostBonus)
+	cash.Importance = widget.HighImportance
+
+	refresh := func() {
+		status.SetText(g.hostStatus())
+		trays.Objects = g.hostTrays()
+		trays.Refresh()
+		for _, b := range []*widget.Button{cash, swap, bonus} {
+			b.Enable()
+			if !g.hostCanOffer() {
+				b.Disable()
+			}
+		}
+		if g.bonus.multiplierUsed && g.bonus.additiveUsed {
+			bonus.Disable()
+		}
+	}
+	g.watch(refresh)
+	refresh()
+
+	hostWindow.SetContent(container.NewBorder(
+		container.NewVBox(status, container.NewHBox(cash, swap, bonus), widget.NewSeparator()),
+		nil, nil, nil,
+		container.NewVScroll(trays),
+	))
+}
+
+// hostTrays is a label per tray with what is inside: the player's tray is
+// bold and opened trays are ticked off
+func (g *Game) hostTrays() []fyne.CanvasObject {
+	labels := []fyne.CanvasObject{}
+	for i := 0; i < g.numTrays; i++ {
+		contents := Money(g.trayValues[i])
+		if g.itemNames[i] != "" {
+			contents = g.itemNames[i]
+		}
+		l := widget.NewLabel(T("host.tray", g.pack.TrayIcon, i+1, contents))
+		switch {
+		case i == g.playerTray:
+			l.SetText("⭐ " + l.Text)
+			l.TextStyle.Bold = true
+		case slices.Contains(g.openedOrder, i):
+			l.SetText("✓ " + l.Text)
+			l.Importance = widget.LowImportance
+		}
+		labels = append(labels, l)
+	}
+	return labels
+}
+
+// hostStatus is the EV, the Chef's offer range and any offer on the table
+func (g *Game) hostStatus() string {
+	if g.playerTray == -1 {
+		return T("host.waiting")
+	}
+	ev, max := g.expectedValue()
+	low, high := g.chef.OfferRange(g.remainingValues())
+	text := T("host.status", Money(ev), Money(max), Money(low), Money(high))
+	if n := len(g.offers); n > 0 && g.offers[n-1].Response == ResponsePending {
+		if g.offers[n-1].Kind == SwapOffer {
+			text += "\n" + T("host.pending_swap")
+		} else {
+			text += "\n" + T("host.pending", Money(g.offers[n-1].Amount))
+		}
+	}
+	return text
+}
+
+// hostCanOffer tells whether the game is at a point where the host may
+// call an offer: the player has a tray and the game is still on
+func (g *Game) hostCanOffer() bool {
+	return g.playerTray != -1 && !g.finished && !g.recorded && !g.playingOut && g.getUnopenedCount() > 0
+}
+
+// hostReady also checks that no tray, offer or bonus dialog is waiting for
+// the player on the audience screen, and tells the host if one is
+func (g *Game) hostReady() bool {
+	if !g.hostCanOffer() {
+		return false
+	}
+	if g.waiting {
+		if hostWindow != nil {
+			dialog.ShowInformation(T("host.busy.title"), T("host.busy.body"), hostWindow)
+		}
+		return false
+	}
+	return true
+}
+
+// hostOffer puts a cash or swap offer to the player right now
+func (g *Game) hostOffer(kind OfferKind) {
+	if !g.hostReady() {
+		return
+	}
+	g.clock.Stop()
+	o := Offer{Kind: SwapOffer, Final: g.getUnopenedCount() == 1}
+	if kind == CashOffer {
+		o = g.cashOffer(g.remainingValues())
+	}
+	o.By = offerByHost
+	g.presentOffer(g.win, o)
+}
+
+// hostBonus starts the bonus round, whether or not the board was dealt
+// one, and follows it with a cash offer
+func (g *Game) hostBonus() {
+	if !g.hostReady() {
+		return
+	}
+	g.clock.Stop()
+	g.bonusOffered = true
+	g.bonus.multiplierActive, g.bonus.additiveActive = true, true
+	g.showBonusSequence(g.win, func() {
+		o := g.cashOffer(g.remainingValues())
+		o.By = offerByHost
+		g.presentOffer(g.win, o)
+	})
+}
@@ -0,0 +1,151 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/test"
+	"fyne.io/fyne/v2/widget"
+)
+
+// newHostGame starts a board in host mode and returns the host console too
+func newHostGame(t *testing.T) (*Game, fyne.Window, fyne.Window) {
+	t.Helper()
+	cfg := testConfig()
+	cfg.HostMode = true
+	g, w := newTestGame(t, cfg)
+	t.Cleanup(func() {
+		if hostWindow != nil {
+			hostWindow.Close()
+		}
+	})
+	if hostWindow == nil {
+		t.Fatal("no host console")
+	}
+	return g, w, hostWindow
+}
+
+// hostButton finds a button on the host console
+func hostButton(t *testing.T, host fyne.Window, text string) *widget.Button {
+	t.Helper()
+	for _, o := range test.LaidOutObjects(host.Content()) {
+		if b, ok := o.(*widget.Button); ok && b.Text == text {
+			return b
+		}
+	}
+	t.Fatalf("no host button %q", text)
+	return nil
+}
+
+// hostShows tells whether a label on the host console contains text
+func hostShows(host fyne.Window, text string) bool {
+	for _, o := range test.LaidOutObjects(host.Content()) {
+		if l, ok := o.(*widget.Label); ok && strings.Contains(l.Text, text) {
+			return true
+		}
+	}
+	return false
+}
+
+func TestHostSeesEveryTray(t *testing.T) {
+	g, w, host := newHostGame(t)
+	for i := 0; i < g.numTrays; i++ {
+		contents := Money(g.trayValues[i])
+		if g.itemNames[i] != "" {
+			contents = g.itemNames[i]
+		}
+		if !hostShows(host, T("host.tray", g.pack.TrayIcon, i+1, contents)) {
+			t.Errorf("tray %d not on the host console", i+1)
+		}
+	}
+	if !hostButton(t, host, T("host.cash_offer")).Disabled() {
+		t.Error("offers are possible before the player has a tray")
+	}
+
+	// the console follows the game in the audience window
+	pickTray(t, g, w, 0)
+	openTray(t, g, w, 1)
+	if !hostShows(host, "✓ "+T("host.tray", g.pack.TrayIcon, 2, "")) {
+		t.Error("opened tray not ticked off on the host console")
+	}
+	ev, max := g.expectedValue()
+	if !hostShows(host, Money(ev)) || !hostShows(host, Money(max)) {
+		t.Error("no EV on the host console")
+	}
+}
+
+func TestHostCallsOffer(t *testing.T) {
+	g, w, host := newHostGame(t)
+	pickTray(t, g, w, 0)
+	openTray(t, g, w, 1)
+
+	cash := hostButton(t, host, T("host.cash_offer"))
+	test.Tap(cash)
+	if len(g.offers) != 1 || g.offers[0].Kind != CashOffer || g.offers[0].By != offerByHost {
+		t.Fatalf("offers = %+v, want one cash offer from the host", g.offers)
+	}
+	low, high := g.chef.OfferRange(g.remainingValues())
+	if a := g.offers[0].Amount; a < low || a > high {
+		t.Errorf("offer %d outside the range %d..%d the host was shown", a, low, high)
+	}
+	if !hostShows(host, T("host.pending", Money(g.offers[0].Amount))) {
+		t.Error("the host does not see the offer on the table")
+	}
+
+	// nothing more while the audience screen waits for an answer
+	test.Tap(cash)
+	if len(g.offers) != 1 {
+		t.Errorf("a second offer was made over the first: %d offers", len(g.offers))
+	}
+	tapDialog(t, w, T("decline"))
+
+	test.Tap(hostButton(t, host, T("host.swap_offer")))
+	if len(g.offers) != 2 || g.offers[1].Kind != SwapOffer {
+		t.Errorf("offers = %+v, want a swap offer", g.offers)
+	}
+}
+
+func TestHostStartsBonusRound(t *testing.T) {
+	g, w, host := newHostGame(t)
+	g.bonus.multiplierActive, g.bonus.additiveActive = false, false
+	pickTray(t, g, w, 0)
+
+	test.Tap(hostButton(t, host, T("host.bonus")))
+	tapDialog(t, w, T("bonus.case", 1))
+	tapDialog(t, w, T("ok"))
+	tapDialog(t, w, T("bonus.case", 1))
+	tapDialog(t, w, T("ok"))
+	if !g.bonus.multiplierUsed || !g.bonus.additiveUsed {
+		t.Error("the bonus round did not run both bonuses")
+	}
+	if len(g.offers) != 1 || g.offers[0].Kind != CashOffer || g.offers[0].By != offerByHost {
+		t.Errorf("offers = %+v, want a cash offer from the host after the bonus", g.offers)
+	}
+	if !hostButton(t, host, T("host.bonus")).Disabled() {
+		t.Error("bonus round still possible with both bonuses used")
+	}
+}
+
+func TestHostWaitsForTheAudienceDialogsOnly(t *testing.T) {
+	g, w, host := newHostGame(t)
+	pickTray(t, g, w, 0)
+
+	// a tray being revealed waits for the player
+	test.Tap(g.gridButtons[1])
+	test.Tap(hostButton(t, host, T("host.cash_offer")))
+	if len(g.offers) != 0 {
+		t.Fatal("the host made an offer over a tray reveal")
+	}
+	tapDialog(t, w, T("ok"))
+
+	// an achievement note does not
+	g.showToast("🏅 test")
+	if g.toast.Text != "🏅 test" || len(w.Canvas().Overlays().List()) != 0 {
+		t.Errorf("toast %q shown as an overlay: %d overlays", g.toast.Text, len(w.Canvas().Overlays().List()))
+	}
+	test.Tap(hostButton(t, host, T("host.cash_offer")))
+	if len(g.offers) != 1 {
+		t.Errorf("offers = %+v, want the host's offer", g.offers)
+	}
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"path"
+	"strconv"
+	"strings"
+)
+
+//go:embed translations/*.json
+var embeddedTranslations embed.FS
+
+// Languages the game ships with, in menu order
+var languages = []struct{ Code, Name string }{
+	{"en", "English"},
+	{"bg", "Български"},
+}
+
+// Currencies money can be shown in. BGN always puts its symbol after the number.
+var currencies = []struct {
+	Code   string
+	Symbol string
+	After  bool
+}{
+	{"USD", "$", false},
+	{"EUR", "€", false},
+	{"BGN", "лв.", true},
+}
+
+// catalogue maps language code -> message key -> text
+var catalogue = loadCatalogue()
+
+// Language and currency in use; set from the settings by applyLocale
+var (
+	currentLang     = "en"
+	currentCurrency = "USD"
+)
+
+func loadCatalogue() map[string]map[string]string {
+	files, err := fs.Glob(embeddedTranslations, "translations/*.json")
+	if err != nil {
+		panic(err)
+	}
+	c := map[string]map[string]string{}
+	for _, f := range files {
+		data, err := embeddedTranslations.ReadFile(f)
+		if err != nil {
+			panic(err)
+		}
+		msgs := map[string]string{}
+		if err := json.Unmarshal(data, &msgs); err != nil {
+			panic(fmt.Sprintf("%s: %v", f, err))
+		}
+		c[strings.TrimSuffix(path.Base(f), ".json")] = msgs
+	}
+	return c
+}
+
+// applyLocale switches language and currency for text made from now on
+func applyLocale(s *Settings) {
+	currentLang = s.Language
+	if _, ok := catalogue[currentLang]; !ok {
+		currentLang = "en"
+	}
+	currentCurrency = s.Currency
+}
+
+// T looks up a message in the current language (falling back to English,
+// then to the key itself) and fills in any arguments
+func T(key string, args ...any) string {
+	msg, ok := catalogue[currentLang][key]
+	if !ok {
+		if msg, ok = catalogue["en"][key]; !ok {
+			msg = key
+		}
+	}
+	if len(args) == 0 {
+		return msg
+	}
+	return fmt.Sprintf(msg, args...)
+}
+
+// Money formats an amount in the current currency with the current
+// language's thousands separator and symbol placement
+func Money(amount int) string {
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+
+	digits := strconv.Itoa(amount)
+	sep := T("number.thousands")
+	var b strings.Builder
+	for i, d := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			b.WriteString(sep)
+		}
+		b.WriteRune(d)
+	}
+	number := b.String()
+
+	symbol, after := "$", false
+	for _, c := range currencies {
+		if c.Code == currentCurrency {
+			symbol, after = c.Symbol, c.After
+		}
+	}
+	if after || T("number.symbol_after") == "true" {
+		return sign + number + " " + symbol
+	}
+	return sign + symbol + number
+}
@@ -0,0 +1,124 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"fyne.io/fyne/v2"
+)
+
+// checkInvariants turns on verify after every action. It is on in tests
+// (see TestMain) and when MEALNOMEAL_CHECK_INVARIANTS is set.
+var checkInvariants = os.Getenv("MEALNOMEAL_CHECK_INVARIANTS") != ""
+
+// invariantBroken is told when verify finds the state broken. The game logs
+// it; tests make it fail instead.
+var invariantBroken = func(action string, err error) {
+	fyne.LogError("game state broken after "+action, err)
+}
+
+// sidebarValue is the value a tray stands for on the sidebar: its own
+// value, or the one its food item replaced
+func (g *Game) sidebarValue(idx int) int {
+	if g.trayReplaced[idx] != -1 {
+		return g.trayReplaced[idx]
+	}
+	return g.trayValues[idx]
+}
+
+// Invariants returns everything wrong with the game state, nil if it is sound
+func (g *Game) Invariants() error {
+	var errs []error
+	fail := func(format string, args ...any) {
+		errs = append(errs, fmt.Errorf(format, args...))
+	}
+
+	// food trays and trayReplaced agree
+	for i := 0; i < g.numTrays; i++ {
+		food := g.trayValues[i] == -1
+		if food != (g.itemNames[i] != "") || food != (g.trayReplaced[i] != -1) {
+			fail("tray %d: value %d, item %q, replaced %d disagree", i+1, g.trayValues[i], g.itemNames[i], g.trayReplaced[i])
+		}
+	}
+
+	// each board value is in exactly one tray
+	count := map[int]int{}
+	for i := 0; i < g.numTrays; i++ {
+		count[g.sidebarValue(i)]++
+	}
+	for _, v := range g.values {
+		if count[v] != 1 {
+			fail("value %d is in %d trays", v, count[v])
+		}
+		delete(count, v)
+	}
+	for v := range count {
+		fail("value %d is in a tray but not on the board", v)
+	}
+
+	// opened trays are off the board, and until the game is over the
+	// others (but the player's) are still on it
+	openedTrays := map[int]bool{}
+	for _, idx := range g.openedOrder {
+		if openedTrays[idx] {
+			fail("tray %d was opened twice", idx+1)
+		}
+		openedTrays[idx] = true
+	}
+	for i := 0; i < g.numTrays; i++ {
+		switch {
+		case openedTrays[i] && i == g.playerTray:
+			fail("the player's tray %d was opened", i+1)
+		case openedTrays[i] && !g.gridButtons[i].Disabled():
+			fail("tray %d was opened but is still on the board", i+1)
+		case !openedTrays[i] && i != g.playerTray && !g.finished && g.gridButtons[i].Disabled():
+			fail("tray %d is off the board but was never opened", i+1)
+		}
+	}
+
+	// opened values are exactly the values of the opened trays
+	opened := map[int]bool{}
+	for idx := range openedTrays {
+		opened[g.sidebarValue(idx)] = true
+	}
+	for v := range opened {
+		if !g.openedValues[v] {
+			fail("value %d is in an opened tray but not marked opened", v)
+		}
+	}
+	for v, ok := range g.openedValues {
+		if ok && !opened[v] {
+			fail("value %d is marked opened but its tray is not", v)
+		}
+	}
+	if g.playerTray != -1 && g.openedValues[g.sidebarValue(g.playerTray)] {
+		fail("the player's tray %d is marked opened", g.playerTray+1)
+	}
+
+	// the sidebar shows the status the model gives each value
+	for i, v := range g.values {
+		shown, _ := g.sidebar[i].Get()
+		if want := g.valueStatus(v); ValueStatus(shown) != want {
+			fail("sidebar shows %d as %d, want %d", v, shown, want)
+		}
+	}
+
+	// the Chef never offers nothing
+	for _, o := range g.offers {
+		if o.Kind == CashOffer && o.Amount <= 0 {
+			fail("round %d: cash offer of %d", o.Round, o.Amount)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// verify logs broken invariants after an action when checking is on
+func (g *Game) verify(action string) {
+	if !checkInvariants {
+		return
+	}
+	if err := g.Invariants(); err != nil {
+		invariantBroken(action, err)
+	}
+}
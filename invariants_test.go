@@ -0,0 +1,141 @@
+package main
+
+import (
+	"math/rand"
+	"strconv"
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/test"
+	"fyne.io/fyne/v2/widget"
+)
+
+// playRandomly answers whatever the game asks with random choices until the
+// Game Over dialog, checking the invariants after every action
+func playRandomly(t *testing.T, g *Game, w fyne.Window, r *rand.Rand) {
+	t.Helper()
+	for step := 0; step < 500; step++ {
+		if err := g.Invariants(); err != nil {
+			t.Fatalf("step %d: %v", step, err)
+		}
+		if findDialogButton(w, T("game_over.setup")) != nil {
+			return
+		}
+
+		switch {
+		case findDialogButton(w, T("accept")) != nil:
+			if r.Intn(5) == 0 {
+				tapDialog(t, w, T("accept"))
+			} else {
+				tapDialog(t, w, T("decline"))
+			}
+		case findDialogButton(w, T("swap.button")) != nil:
+			for _, o := range overlayObjects(w) {
+				if s, ok := o.(*widget.Select); ok {
+					s.SetSelected(s.Options[r.Intn(len(s.Options))])
+				}
+			}
+			tapDialog(t, w, T("swap.button"))
+		case findDialogButton(w, T("bonus.case", 1)) != nil:
+			tapDialog(t, w, T("bonus.case", 1+r.Intn(5)))
+		case findDialogButton(w, T("final.swap", g.lastOtherTray()+1)) != nil:
+			if r.Intn(2) == 0 {
+				tapDialog(t, w, T("final.swap", g.lastOtherTray()+1))
+			} else {
+				tapDialog(t, w, T("final.keep", g.pack.TrayIcon, g.playerTray+1))
+			}
+		case findDialogButton(w, T("continue")) != nil:
+			tapDialog(t, w, T("continue"))
+		case findDialogButton(w, "OK") != nil:
+			tapDialog(t, w, "OK")
+		default:
+			closed := []int{}
+			for i, b := range g.gridButtons {
+				if i != g.playerTray && !b.Disabled() {
+					closed = append(closed, i)
+				}
+			}
+			if len(closed) == 0 {
+				t.Fatalf("step %d: nothing to do and no Game Over", step)
+			}
+			test.Tap(g.gridButtons[closed[r.Intn(len(closed))]])
+		}
+	}
+	t.Fatal("the game did not end")
+}
+
+// randomConfig varies the setup along with the moves
+func randomConfig(r *rand.Rand, seed int64) GameConfig {
+	cfg := DefaultGameConfig()
+	cfg.Seed = seed
+	cfg.NumTrays = 6 + r.Intn(NUM_TRAYS-5)
+	cfg.Difficulty = difficulties[r.Intn(len(difficulties))].Name
+	cfg.BonusChance = r.Float64()
+	cfg.MaxItems = r.Intn(6)
+	return cfg
+}
+
+func TestRandomGamesKeepInvariants(t *testing.T) {
+	if testing.Short() {
+		t.Skip("plays many whole games")
+	}
+	for seed := int64(1); seed <= 25; seed++ {
+		t.Run(strconv.FormatInt(seed, 10), func(t *testing.T) {
+			r := rand.New(rand.NewSource(seed))
+			g, w := newTestGame(t, randomConfig(r, seed))
+			playRandomly(t, g, w, r)
+		})
+	}
+}
+
+func FuzzGameInvariants(f *testing.F) {
+	f.Add(int64(1), int64(2))
+	f.Add(int64(42), int64(7))
+	f.Add(int64(-5), int64(0))
+	f.Fuzz(func(t *testing.T, boardSeed, moveSeed int64) {
+		if boardSeed == 0 {
+			boardSeed = 1
+		}
+		r := rand.New(rand.NewSource(moveSeed))
+		g, w := newTestGame(t, randomConfig(r, boardSeed))
+		playRandomly(t, g, w, r)
+	})
+}
+
+func TestInvariantsCatchBrokenState(t *testing.T) {
+	tests := []struct {
+		name    string
+		breakIt func(g *Game)
+	}{
+		{"value in two trays", func(g *Game) {
+			if g.trayValues[1] == -1 || g.trayValues[2] == -1 {
+				g.trayValues[1], g.trayReplaced[1], g.itemNames[1] = 5, -1, ""
+				g.trayValues[2], g.trayReplaced[2], g.itemNames[2] = 5, -1, ""
+				return
+			}
+			g.trayValues[1] = g.trayValues[2]
+		}},
+		{"player's tray opened", func(g *Game) { g.openedValues[g.sidebarValue(g.playerTray)] = true }},
+		{"food without item", func(g *Game) { g.trayValues[3], g.itemNames[3] = -1, "" }},
+		{"tray off the board but not opened", func(g *Game) { g.gridButtons[5].Disable() }},
+		{"opened tray not marked", func(g *Game) {
+			g.gridButtons[5].Disable()
+			g.openedOrder = append(g.openedOrder, 5)
+		}},
+		{"sidebar out of date", func(g *Game) { g.sidebar[0].Set(int(ValueOpened)) }},
+		{"empty offer", func(g *Game) { g.offers = append(g.offers, OfferRecord{Round: 1, Kind: CashOffer}) }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, w := newTestGame(t, testConfig())
+			pickTray(t, g, w, 0)
+			if err := g.Invariants(); err != nil {
+				t.Fatalf("fresh game already broken: %v", err)
+			}
+			tt.breakIt(g)
+			if g.Invariants() == nil {
+				t.Error("broken state not noticed")
+			}
+		})
+	}
+}
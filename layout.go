@@ -0,0 +1,214 @@
+package main
+
+import (
+	"math"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// Default window size; the board reflows to whatever the window becomes
+var defaultWindowSize = fyne.NewSize(1000, 600)
+
+// Board widths below which the history panel, then the sidebars, move into
+// the drawer. The default window is wide enough for everything.
+const (
+	wideBoard   = 960
+	narrowBoard = 760
+)
+
+// TV mode: full screen with everything drawn bigger, for office screens
+const tvZoom = 1.5
+
+var (
+	tvMode  bool
+	uiScale float32 = 1 // images are drawn at this scale; follows the window size
+)
+
+// scaled is a picture size adjusted to the window
+func scaled(v float32) float32 {
+	return v * uiScale
+}
+
+// applyTVMode switches full screen and the bigger theme on or off
+func applyTVMode(w fyne.Window, on bool) {
+	tvMode = on
+	w.SetFullScreen(on)
+	fyne.CurrentApp().Settings().SetTheme(newPackTheme(currentPack))
+}
+
+// trayGridLayout puts the trays in as many columns as fit (3 to 10) and
+// makes them taller when there is room
+type trayGridLayout struct{}
+
+func (trayGridLayout) cell(objects []fyne.CanvasObject) fyne.Size {
+	cell := fyne.NewSize(0, 0)
+	for _, o := range objects {
+		cell = cell.Max(o.MinSize())
+	}
+	return cell
+}
+
+func (l trayGridLayout) columns(width float32, objects []fyne.CanvasObject) int {
+	pad := theme.Padding()
+	cols := int((width + pad) / (l.cell(objects).Width + pad))
+	cols = max(3, min(cols, 10, len(objects)))
+	return cols
+}
+
+func (l trayGridLayout) MinSize(objects []fyne.CanvasObject) fyne.Size {
+	if len(objects) == 0 {
+		return fyne.NewSize(0, 0)
+	}
+	cell, pad := l.cell(objects), theme.Padding()
+	cols := min(3, len(objects))
+	rows := (len(objects) + cols - 1) / cols
+	return fyne.NewSize(float32(cols)*(cell.Width+pad)-pad, float32(rows)*(cell.Height+pad)-pad)
+}
+
+func (l trayGridLayout) Layout(objects []fyne.CanvasObject, size fyne.Size) {
+	if len(objects) == 0 {
+		return
+	}
+	cell, pad := l.cell(objects), theme.Padding()
+	cols := l.columns(size.Width, objects)
+	rows := (len(objects) + cols - 1) / cols
+	w := (size.Width - float32(cols-1)*pad) / float32(cols)
+	h := (size.Height - float32(rows-1)*pad) / float32(rows)
+	h = float32(math.Max(float64(cell.Height), math.Min(float64(h), float64(w)*0.75)))
+	for i, o := range objects {
+		o.Move(fyne.NewPos(float32(i%cols)*(w+pad), float32(i/cols)*(h+pad)))
+		o.Resize(fyne.NewSize(w, h))
+	}
+}
+
+// boardZoom is how much bigger everything is drawn
+func boardZoom() float32 {
+	if tvMode {
+		return tvZoom
+	}
+	return 1
+}
+
+// boardFits tells, for a board width, whether the history panel and the
+// sidebars fit next to the trays
+func boardFits(width float32) (history, sidebars bool) {
+	return width >= wideBoard*boardZoom(), width >= narrowBoard*boardZoom()
+}
+
+// boardView is the board. Its size sets the picture scale and whether the
+// drawer is needed; boardLayout only places the parts.
+type boardView struct {
+	widget.BaseWidget
+	content  *fyne.Container
+	onDrawer func(needed bool) // told when the drawer becomes needed or not
+	drawer   bool
+	sized    bool
+}
+
+// newBoardView shows the left sidebar, grid, right sidebar and history
+func newBoardView(onDrawer func(needed bool), left, grid, right, history fyne.CanvasObject) *boardView {
+	b := &boardView{content: container.New(&boardLayout{}, left, grid, right, history), onDrawer: onDrawer}
+	b.ExtendBaseWidget(b)
+	return b
+}
+
+func (b *boardView) CreateRenderer() fyne.WidgetRenderer {
+	return widget.NewSimpleRenderer(b.content)
+}
+
+func (b *boardView) Resize(size fyne.Size) {
+	uiScale = boardZoom() * float32(math.Max(0.6, math.Min(1.6, math.Min(float64(size.Width/defaultWindowSize.Width), float64(size.Height/defaultWindowSize.Height)))))
+	history, _ := boardFits(size.Width)
+	if needed := !history; b.onDrawer != nil && (!b.sized || needed != b.drawer) {
+		b.drawer = needed
+		b.onDrawer(needed)
+	}
+	b.sized = true
+	b.BaseWidget.Resize(size)
+}
+
+// boardLayout places the sidebars, tray grid and offer history side by
+// side, dropping the history and then the sidebars on smaller windows.
+// Objects are left, grid, right, history.
+type boardLayout struct{}
+
+func (l *boardLayout) MinSize(objects []fyne.CanvasObject) fyne.Size {
+	return objects[1].MinSize()
+}
+
+func (l *boardLayout) Layout(objects []fyne.CanvasObject, size fyne.Size) {
+	left, grid, right, history := objects[0], objects[1], objects[2], objects[3]
+	pad := theme.Padding()
+
+	wide, sidebars := boardFits(size.Width)
+	x, end := float32(0), size.Width
+	place := func(o fyne.CanvasObject, show bool, atEnd bool) {
+		if !show {
+			o.Hide()
+			return
+		}
+		o.Show()
+		w := o.MinSize().Width
+		if atEnd {
+			end -= w
+			o.Move(fyne.NewPos(end, 0))
+			end -= pad
+		} else {
+			o.Move(fyne.NewPos(x, 0))
+			x += w + pad
+		}
+		o.Resize(fyne.NewSize(w, size.Height))
+	}
+	place(history, wide, true)
+	place(left, sidebars, false)
+	place(right, sidebars, true)
+	grid.Move(fyne.NewPos(x, 0))
+	grid.Resize(fyne.NewSize(end-x, size.Height))
+}
+
+// cardColumn puts each value into a small card (white box) for readability
+func cardColumn(cells []*valueCell) *fyne.Container {
+	box := container.NewVBox()
+	for _, c := range cells {
+		box.Add(widget.NewCard("", "", c))
+	}
+	return box
+}
+
+// sidebarColumn is a scrollable column of value cards whose width is that
+// of the cards
+func sidebarColumn(cells []*valueCell) fyne.CanvasObject {
+	box := cardColumn(cells)
+	scroll := container.NewVScroll(box)
+	scroll.SetMinSize(fyne.NewSize(box.MinSize().Width, 0))
+	return scroll
+}
+
+// showDrawer opens the side drawer with what did not fit on the board:
+// the values still in play and the offers so far
+func (g *Game) showDrawer() {
+	if g.drawerCells == nil {
+		for i, v := range g.values {
+			value := v
+			g.drawerCells = append(g.drawerCells, newValueCell(g.sidebar[i], func(s ValueStatus) string {
+				return g.valueText(value, s)
+			}))
+		}
+	}
+	for _, c := range g.drawerCells {
+		c.update()
+	}
+	half := g.sidebarSplit()
+	content := container.NewHBox(
+		sidebarColumn(g.drawerCells[:half]),
+		sidebarColumn(g.drawerCells[half:]),
+		container.NewVScroll(g.offerSummary()),
+	)
+	c := g.win.Canvas()
+	drawer := widget.NewPopUp(content, c)
+	drawer.Resize(fyne.NewSize(min(content.MinSize().Width, c.Size().Width), c.Size().Height))
+	drawer.ShowAtPosition(fyne.NewPos(0, 0))
+}
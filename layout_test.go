@@ -0,0 +1,114 @@
+package main
+
+import (
+	"slices"
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/test"
+	"fyne.io/fyne/v2/widget"
+)
+
+func TestTrayGridColumns(t *testing.T) {
+	test.NewTempApp(t)
+	objects := []fyne.CanvasObject{}
+	for range 26 {
+		r := canvas.NewRectangle(nil)
+		r.SetMinSize(fyne.NewSize(96, 40))
+		objects = append(objects, r)
+	}
+	l := trayGridLayout{}
+	tests := []struct {
+		width float32
+		want  int
+	}{
+		{100, 3}, // never fewer than 3
+		{300, 3},
+		{500, 5},
+		{1000, 10},
+		{3000, 10}, // never more than 10
+	}
+	for _, tt := range tests {
+		if got := l.columns(tt.width, objects); got != tt.want {
+			t.Errorf("columns(%v) = %d, want %d", tt.width, got, tt.want)
+		}
+	}
+	if got := l.columns(3000, objects[:4]); got != 4 {
+		t.Errorf("columns with 4 trays = %d, want 4", got)
+	}
+}
+
+func TestBoardReflows(t *testing.T) {
+	g, w := newTestGame(t, testConfig())
+	board := g.gridButtons[0]
+	var parts []fyne.CanvasObject // left, grid, right, history
+	for _, o := range test.LaidOutObjects(w.Content()) {
+		if c, ok := o.(*fyne.Container); ok {
+			if _, ok := c.Layout.(*boardLayout); ok {
+				parts = c.Objects
+			}
+		}
+	}
+	if parts == nil {
+		t.Fatal("no board layout")
+	}
+	sidebar := func() bool { return parts[0].Visible() && parts[2].Visible() }
+	history := func() bool { return parts[3].Visible() }
+
+	tests := []struct {
+		name             string
+		size             fyne.Size
+		sidebar, history bool
+	}{
+		{"wide", fyne.NewSize(1400, 800), true, true},
+		{"default", defaultWindowSize, true, true},
+		{"medium", fyne.NewSize(900, 600), true, false},
+		{"narrow", fyne.NewSize(480, 800), false, false},
+	}
+	for _, tt := range tests {
+		w.Resize(tt.size)
+		if got := sidebar(); got != tt.sidebar {
+			t.Errorf("%s: sidebar shown = %v, want %v", tt.name, got, tt.sidebar)
+		}
+		if got := history(); got != tt.history {
+			t.Errorf("%s: history shown = %v, want %v", tt.name, got, tt.history)
+		}
+		if board.Size().Width <= 0 || board.Position().X+board.Size().Width > tt.size.Width {
+			t.Errorf("%s: tray %v at %v does not fit", tt.name, board.Size(), board.Position())
+		}
+	}
+
+	// what no longer fits can be opened in the drawer
+	g.showDrawer()
+	if len(w.Canvas().Overlays().List()) == 0 {
+		t.Fatal("drawer not shown")
+	}
+}
+
+func TestImagesFollowScale(t *testing.T) {
+	test.NewTempApp(t)
+	defer func() { uiScale = 1 }()
+	uiScale = 1.5
+	if got := loadImage("value_1", 200, 200).MinSize(); got.Width != 300 {
+		t.Errorf("image min size = %v, want 300 wide", got)
+	}
+}
+
+func TestBoardViewTellsDrawerChanges(t *testing.T) {
+	test.NewTempApp(t)
+	defer func() { uiScale = 1 }()
+	calls := []bool{}
+	parts := []fyne.CanvasObject{widget.NewLabel("left"), widget.NewLabel("grid"), widget.NewLabel("right"), widget.NewLabel("history")}
+	b := newBoardView(func(needed bool) { calls = append(calls, needed) }, parts[0], parts[1], parts[2], parts[3])
+
+	for _, size := range []fyne.Size{defaultWindowSize, defaultWindowSize, fyne.NewSize(800, 600), fyne.NewSize(700, 600), fyne.NewSize(2000, 1200)} {
+		b.Resize(size)
+	}
+	if want := []bool{false, true, false}; !slices.Equal(calls, want) {
+		t.Errorf("onDrawer calls = %v, want %v", calls, want)
+	}
+	if uiScale != 1.6 {
+		t.Errorf("uiScale = %v after a big board, want 1.6", uiScale)
+	}
+}
@@ -0,0 +1,221 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+// Chef generosity choices on the start screen
+var generosityLevels = []struct {
+	key   string
+	value float64
+}{
+	{"lobby.generosity.stingy", 0.8},
+	{"lobby.generosity.fair", 1.0},
+	{"lobby.generosity.generous", 1.2},
+}
+
+// startGame deals a new board with cfg and shows it in w
+func startGame(w fyne.Window, cfg GameConfig) *Game {
+	clearSavedGame(fyne.CurrentApp().Preferences())
+	g := NewGame(cfg)
+	g.win = w
+	g.initialize()
+	g.showBoard(fyne.CurrentApp(), nil)
+	return g
+}
+
+// sliderRow is a slider with its current value shown on the right
+func sliderRow(s *widget.Slider, format func(float64) string) fyne.CanvasObject {
+	value := widget.NewLabel(format(s.Value))
+	s.OnChanged = func(v float64) { value.SetText(format(v)) }
+	return container.NewBorder(nil, nil, nil, value, s)
+}
+
+// showLobby is the start screen where the player sets up the next game.
+// The choices are remembered in the preferences; the options the game was
+// started with go on top for this run only.
+func showLobby(w fyne.Window) {
+	prefs := fyne.CurrentApp().Preferences()
+	cfg := LoadGameConfig(prefs)
+	leaveGame()
+
+	name := widget.NewEntry()
+	name.SetPlaceHolder(T("lobby.name_placeholder"))
+	name.SetText(cfg.PlayerName)
+
+	trays := widget.NewSlider(6, NUM_TRAYS)
+	trays.Step = 1
+	trays.Value = float64(cfg.NumTrays)
+
+	levels := []string{}
+	chosenLevel := T(generosityLevels[1].key)
+	for _, l := range generosityLevels {
+		levels = append(levels, T(l.key))
+		if l.value == cfg.Generosity {
+			chosenLevel = T(l.key)
+		}
+	}
+	generosity := widget.NewSelect(levels, nil)
+	generosity.SetSelected(chosenLevel)
+
+	names := []string{}
+	chosenDifficulty := T("difficulty.normal")
+	for _, d := range difficulties {
+		names = append(names, T("difficulty."+d.Name))
+		if d.Name == cfg.Difficulty {
+			chosenDifficulty = T("difficulty." + d.Name)
+		}
+	}
+	difficulty := widget.NewSelect(names, nil)
+	difficulty.SetSelected(chosenDifficulty)
+
+	bonus := widget.NewSlider(0, 100)
+	bonus.Step = 5
+	bonus.Value = cfg.BonusChance * 100
+
+	items := widget.NewSlider(0, 5)
+	items.Step = 1
+	items.Value = float64(cfg.MaxItems)
+
+	timed := widget.NewCheck(T("lobby.timed.on"), nil)
+	timed.SetChecked(cfg.TimedMode)
+	pick := widget.NewSlider(5, 60)
+	pick.Step = 5
+	pick.Value = float64(cfg.PickSeconds)
+	decision := widget.NewSlider(5, 30)
+	decision.Step = 5
+	decision.Value = float64(cfg.DecisionSeconds)
+	bank := widget.NewSlider(0, 120)
+	bank.Step = 10
+	bank.Value = float64(cfg.TimeBank)
+
+	host := widget.NewCheck(T("lobby.host.on"), nil)
+	host.SetChecked(cfg.HostMode)
+	banker := widget.NewCheck(T("lobby.banker.on"), nil)
+	banker.SetChecked(cfg.ManualBanker)
+
+	seed := widget.NewEntry()
+	seed.SetPlaceHolder(T("lobby.seed_placeholder"))
+	if cfg.Seed != 0 {
+		seed.SetText(strconv.FormatInt(cfg.Seed, 10))
+	}
+	seed.Validator = func(s string) error {
+		if strings.TrimSpace(s) == "" {
+			return nil
+		}
+		if _, err := strconv.ParseInt(strings.TrimSpace(s), 10, 64); err != nil {
+			return errors.New(T("lobby.seed_invalid"))
+		}
+		return nil
+	}
+
+	count := func(v float64) string { return fmt.Sprintf("%.0f", v) }
+	percent := func(v float64) string { return fmt.Sprintf("%.0f%%", v) }
+	seconds := func(v float64) string { return T("lobby.seconds", int(v)) }
+	bonusRow := sliderRow(bonus, percent)
+	itemsRow := sliderRow(items, count)
+
+	// Picking a preset fills in its bonus chance and food items; they can
+	// still be changed by hand afterwards
+	difficulty.OnChanged = func(s string) {
+		for i, n := range names {
+			if n == s {
+				bonus.SetValue(difficulties[i].BonusChance * 100)
+				items.SetValue(float64(difficulties[i].MaxItems))
+			}
+		}
+	}
+
+	form := widget.NewForm(
+		widget.NewFormItem(T("lobby.name"), name),
+		widget.NewFormItem(T("lobby.difficulty"), difficulty),
+		widget.NewFormItem(T("lobby.trays"), sliderRow(trays, count)),
+		widget.NewFormItem(T("lobby.generosity"), generosity),
+		widget.NewFormItem(T("lobby.bonus"), bonusRow),
+		widget.NewFormItem(T("lobby.items"), itemsRow),
+		widget.NewFormItem(T("lobby.seed"), seed),
+		widget.NewFormItem(T("lobby.timed"), timed),
+		widget.NewFormItem(T("lobby.pick_seconds"), sliderRow(pick, seconds)),
+		widget.NewFormItem(T("lobby.decision_seconds"), sliderRow(decision, seconds)),
+		widget.NewFormItem(T("lobby.time_bank"), sliderRow(bank, seconds)),
+		widget.NewFormItem(T("lobby.host"), host),
+		widget.NewFormItem(T("lobby.banker"), banker),
+	)
+
+	start := widget.NewButton(T("lobby.start"), func() {
+		if seed.Validate() != nil {
+			return
+		}
+		cfg.PlayerName = strings.TrimSpace(name.Text)
+		cfg.NumTrays = int(trays.Value)
+		cfg.BonusChance = bonus.Value / 100
+		cfg.MaxItems = int(items.Value)
+		cfg.Seed, _ = strconv.ParseInt(strings.TrimSpace(seed.Text), 10, 64)
+		cfg.TimedMode = timed.Checked
+		cfg.PickSeconds = int(pick.Value)
+		cfg.DecisionSeconds = int(decision.Value)
+		cfg.TimeBank = int(bank.Value)
+		cfg.HostMode = host.Checked
+		cfg.ManualBanker = banker.Checked
+		for i, n := range names {
+			if n == difficulty.Selected {
+				cfg.Difficulty = difficulties[i].Name
+			}
+		}
+		for i, l := range levels {
+			if l == generosity.Selected {
+				cfg.Generosity = generosityLevels[i].value
+			}
+		}
+		play := runOptions.Game(cfg)
+		if _, err := chefFor(play, 0); err != nil {
+			dialog.ShowError(err, w)
+			return
+		}
+		cfg.Save(prefs)
+		startGame(w, play)
+	})
+	start.Importance = widget.HighImportance
+	daily := widget.NewButton(T("daily.title"), func() { showDailyDialog(w) })
+	tournament := widget.NewButton(T("tournament.title"), func() { showTournament(w) })
+	achievements := widget.NewButton(T("achievements.title"), func() {
+		showAchievements(w, strings.TrimSpace(name.Text))
+	})
+
+	history := widget.NewButton(T("results.title"), func() { showResults(w) })
+
+	buttons := container.NewHBox(start, daily, tournament, achievements, history)
+	if mobileUI {
+		buttons = container.NewGridWithColumns(2, start, daily, tournament, achievements, history)
+	}
+	if saved := LoadSavedGame(prefs); saved != nil {
+		resume := widget.NewButton(T("lobby.resume"), func() { resumeGame(w, saved) })
+		resume.Importance = widget.HighImportance
+		buttons.Objects = append([]fyne.CanvasObject{resume}, buttons.Objects...)
+	}
+	box := container.NewVBox(
+		container.NewCenter(widget.NewLabel(T("lobby.welcome"))),
+		form,
+		container.NewCenter(buttons),
+	)
+	// on a phone the form takes the whole width and scrolls
+	var body fyne.CanvasObject = container.NewCenter(container.NewGridWrap(fyne.NewSize(420, box.MinSize().Height), box))
+	if mobileUI {
+		body = container.NewVScroll(box)
+	}
+	w.SetContent(container.NewBorder(
+		container.NewCenter(widget.NewLabel(currentPack.Title)),
+		nil,
+		nil,
+		nil,
+		body,
+	))
+}
@@ -1,17 +1,19 @@
 package main
 
 import (
+	"errors"
+	"flag"
 	"fmt"
 	"math/rand"
+	"os"
 	"strconv"
 	"time"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/app"
-	"fyne.io/fyne/v2/canvas"
 	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/data/binding"
 	"fyne.io/fyne/v2/dialog"
-	"fyne.io/fyne/v2/storage"
 	"fyne.io/fyne/v2/widget"
 )
 
@@ -29,49 +31,85 @@ var VALUES = []int{
 type Game struct {
 	win              fyne.Window
 	gridButtons      []*widget.Button
-	leftLabels       []*widget.Label
-	rightLabels      []*widget.Label
+	sidebar          []binding.Int // status of each board value, see ValueStatus
+	sidebarCells     []*valueCell  // the sidebar entries bound to it
+	drawerCells      []*valueCell  // the same entries in the drawer on small windows
 	trayValues       []int
 	trayReplaced     []int    // if tray had an item, stores the numeric value removed
 	itemNames        []string // "" if none
-	itemImages       []int    // food cartoon image IDs for items
+	itemImages       []string // food cartoon asset names for items
 	playerTray       int
+	pickedTray       int            // the tray first chosen, before any swaps
 	playerTrayButton *widget.Button // visual representation of player's tray
+	myTrayLabel      *widget.Label
 	openedTraysCount int
 	openedValues     map[int]bool
+	revealing        int // tray whose lid is still coming off, not struck off the sidebar yet; -1 if none
 	chef             *Chef
 	bonus            *BonusManager
 	bonusOffered     bool // track if bonus has been offered this game
+	sound            *Sound
+	pack             *ThemePack
+	title            *widget.Label
+	config           GameConfig
+	seed             int64 // seed the board and the Chef were made from
+	numTrays         int
+	values           []int // values on this board, lowest first
+	offers           []OfferRecord
+	history          *fyne.Container // offer history panel next to the board
+	playingOut       bool            // deal taken, the board goes on just to show what would have happened
+	dealAmount       int             // the deal the player took
+	daily            string          // date of the daily challenge, "" for a normal game
+	openedOrder      []int           // trays in the order they were opened
+	tournamentGame   int             // game number in the running tournament, 0 if none
+	clock            *Clock          // nil unless the game is timed
+	finished         bool            // the game is over and the board locked
+	recorded         bool            // the result is in the history
+	watchers         []func()        // told about every change, see watch
+	waiting          bool            // a tray, offer or bonus dialog waits for the player
+	toast            *widget.Label   // short notes in the top bar, see showToast
+	toastAnim        *fyne.Animation
 }
 
-func NewGame() *Game {
-	return &Game{
-		playerTray:   -1,
-		chef:         NewChef(),
-		bonus:        NewBonusManager(),
-		openedValues: make(map[int]bool),
-		bonusOffered: false,
-	}
-}
-
-// Helper function to load image from file with better error handling
-func loadImage(filename string, width, height float32) fyne.CanvasObject {
-	uri := storage.NewFileURI("images/" + filename)
-	img := canvas.NewImageFromURI(uri)
+// activeGame is the game currently shown in the window
+var activeGame *Game
 
-	// Check if image loaded successfully
-	if img == nil {
-		// Return placeholder if image fails to load
-		return widget.NewLabel(fmt.Sprintf("Image: %s", filename))
+func NewGame(cfg GameConfig) *Game {
+	seed := cfg.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
 	}
+	values := boardValues(cfg.NumTrays)
 
-	img.FillMode = canvas.ImageFillContain
-	img.SetMinSize(fyne.NewSize(width, height))
-	return img
+	g := &Game{
+		playerTray:   -1,
+		revealing:    -1,
+		bonus:        NewBonusManager(seed + 2),
+		openedValues: make(map[int]bool),
+		bonusOffered: false,
+		sound:        gameSound,
+		pack:         currentPack,
+		config:       cfg,
+		seed:         seed,
+		numTrays:     len(values),
+		values:       values,
+	}
+	difficulty := difficultyByName(cfg.Difficulty)
+	g.bonus.sound = g.sound
+	g.bonus.Tune(difficulty)
+	var err error
+	if g.chef, err = chefFor(cfg, seed+1); err != nil {
+		fyne.LogError("Chef overrides ignored", err)
+	}
+	g.chef.art = g.pack.BankerArt
+	if cfg.TimedMode {
+		g.clock = NewClock(time.Duration(cfg.TimeBank) * time.Second)
+	}
+	return g
 }
 
 // Helper function to get the closest value image for an offer
-func (g *Game) getOfferImageID(offer int) int {
+func (g *Game) offerAsset(offer int) string {
 	// Find the closest value in VALUES array
 	closestIdx := 0
 	minDiff := abs(VALUES[0] - offer)
@@ -84,7 +122,7 @@ func (g *Game) getOfferImageID(offer int) int {
 		}
 	}
 
-	return closestIdx + 1 // Return 1-based index for image naming
+	return valueAsset(VALUES[closestIdx])
 }
 
 func abs(x int) int {
@@ -95,33 +133,37 @@ func abs(x int) int {
 }
 
 func (g *Game) initialize() {
-	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	r := rand.New(rand.NewSource(g.seed))
 
-	// shuffle VALUES and assign one-to-one
-	sh := make([]int, len(VALUES))
-	copy(sh, VALUES)
+	// shuffle the board values and assign one-to-one
+	sh := make([]int, len(g.values))
+	copy(sh, g.values)
 	r.Shuffle(len(sh), func(i, j int) { sh[i], sh[j] = sh[j], sh[i] })
 
-	g.trayValues = make([]int, NUM_TRAYS)
-	for i := 0; i < NUM_TRAYS; i++ {
+	g.trayValues = make([]int, g.numTrays)
+	for i := 0; i < g.numTrays; i++ {
 		g.trayValues[i] = sh[i%len(sh)]
 	}
 
 	// init arrays
-	g.itemNames = make([]string, NUM_TRAYS)
-	g.itemImages = make([]int, NUM_TRAYS)
-	g.trayReplaced = make([]int, NUM_TRAYS)
+	g.itemNames = make([]string, g.numTrays)
+	g.itemImages = make([]string, g.numTrays)
+	g.trayReplaced = make([]int, g.numTrays)
 	for i := range g.trayReplaced {
 		g.trayReplaced[i] = -1
 	}
 
-	// choose 0..3 item replacements (avoid first/last)
-	numItems := r.Intn(4)
+	// choose 0..MaxItems item replacements (avoid first/last)
+	numItems := r.Intn(g.config.MaxItems + 1)
+	if numItems > g.numTrays-2 {
+		numItems = g.numTrays - 2
+	}
+	lowest, highest := g.values[0], g.values[len(g.values)-1]
 	replace := map[int]bool{}
 	for len(replace) < numItems {
-		idx := r.Intn(NUM_TRAYS)
+		idx := r.Intn(g.numTrays)
 		// Skip if this tray contains lowest or highest value
-		if g.trayValues[idx] == VALUES[0] || g.trayValues[idx] == VALUES[len(VALUES)-1] {
+		if g.trayValues[idx] == lowest || g.trayValues[idx] == highest {
 			continue
 		}
 		if !replace[idx] {
@@ -129,93 +171,127 @@ func (g *Game) initialize() {
 		}
 	}
 
-	// Food-themed items pool (cartoon food images: 51-100)
-	foodItems := []struct {
-		name    string
-		imageID int
-	}{
-		{"Beigners", 51},
-		{"Cheese Sandwich", 52},
-		{"Magic cookies", 53},
-		{"Ultimate sandwich", 54},
-		{"Pretty patty", 55},
-		{"hors d'oeuvres", 56},
-		{"Nacco", 57},
-		{"Krabby patty", 58},
-		{"jr. patty", 59},
-		{"Poritage", 60},
-		{"Hot Dog", 61},
-		{"Ramen", 62},
-		{"Chilli fries", 63},
-		{"Ultimate Sandwich", 64},
-		{"Spanish puffs", 65},
-		{"Turkey", 66},
-		{"Dreamy breakfast", 67},
-		{"ratatouille", 68},
-	}
-
+	// Items come from the theme pack's pool
 	for idx := range replace {
-		food := foodItems[r.Intn(len(foodItems))]
-		g.itemNames[idx] = food.name
-		g.itemImages[idx] = food.imageID
+		item := g.pack.RandomItem(r)
+		g.itemNames[idx] = item.Name
+		g.itemImages[idx] = item.Asset
 		g.trayReplaced[idx] = g.trayValues[idx]
 		g.trayValues[idx] = -1 // mark as item
 	}
 
-	// Build display sidebar in VALUES order; mark removed numeric values as FOOD ITEM sentinel
-	display := make([]int, len(VALUES))
-	copy(display, VALUES)
+	// Sidebar entries in value order, bound to the status of each value
+	g.sidebar = make([]binding.Int, len(g.values))
+	g.sidebarCells = make([]*valueCell, len(g.values))
+	for i, v := range g.values {
+		value := v
+		g.sidebar[i] = binding.NewInt()
+		g.sidebarCells[i] = newValueCell(g.sidebar[i], func(s ValueStatus) string {
+			return g.valueText(value, s)
+		})
+	}
+	g.watch(g.syncSidebar)
+	g.syncSidebar()
+}
 
-	removed := map[int]bool{}
-	for _, v := range g.trayReplaced {
-		if v != -1 {
-			removed[v] = true
+// showBoard puts the title, sidebars and tray grid into the window, with an
+// optional bar at the bottom
+func (g *Game) showBoard(a fyne.App, bottom fyne.CanvasObject) {
+	fresh := activeGame != g
+	if fresh {
+		leaveGame()
+	}
+	g.title = widget.NewLabel(g.pack.Title)
+	g.toast = widget.NewLabel("")
+	g.toast.Hide()
+	drawerBtn := widget.NewButton("☰ "+T("board.drawer"), g.showDrawer)
+	drawerBtn.Hide()
+	top := container.NewVBox(container.NewBorder(nil, nil, drawerBtn, g.toast, container.NewCenter(g.title)))
+	if g.clock != nil {
+		top.Add(container.NewCenter(g.clock.label))
+	}
+	content := g.setupUI(a, func(needed bool) {
+		if needed {
+			drawerBtn.Show()
+		} else {
+			drawerBtn.Hide()
 		}
+	})
+	g.win.SetContent(container.NewBorder(
+		top,
+		bottom,
+		nil,
+		nil,
+		content,
+	))
+	activeGame = g
+	if fresh && g.config.HostMode {
+		g.showHostConsole()
 	}
-	for i := 0; i < len(display); i++ {
-		if removed[display[i]] {
-			// sentinel
-			display[i] = -999999
-		}
+	if g.playerTray == -1 {
+		g.startPickClock()
 	}
+}
 
-	half := len(VALUES) / 2
-	g.leftLabels = make([]*widget.Label, half)
-	g.rightLabels = make([]*widget.Label, half)
+// watch calls f after every change to the game, such as a tray opened or
+// an offer answered
+func (g *Game) watch(f func()) {
+	g.watchers = append(g.watchers, f)
+}
 
-	for i := 0; i < half; i++ {
-		ltext := fmt.Sprintf("$%d", display[i])
-		if display[i] == -999999 {
-			ltext = "🍔 FOOD ITEM"
-		}
-		g.leftLabels[i] = widget.NewLabel(ltext)
+// changed tells the watchers that the game changed
+func (g *Game) changed() {
+	for _, f := range g.watchers {
+		f()
+	}
+}
 
-		rtext := fmt.Sprintf("$%d", display[i+half])
-		if display[i+half] == -999999 {
-			rtext = "🍔 FOOD ITEM"
-		}
-		g.rightLabels[i] = widget.NewLabel(rtext)
+// leaveGame stops the game on screen, if any, before something else is shown
+func leaveGame() {
+	if activeGame != nil {
+		activeGame.clock.Stop()
 	}
+	activeGame = nil
+}
+
+// applyThemePack relabels the board after the theme pack changed
+func (g *Game) applyThemePack(p *ThemePack) {
+	g.pack = p
+	g.chef.art = p.BankerArt
+	g.relabel()
 }
 
-func (g *Game) setupUI(a fyne.App) fyne.CanvasObject {
-	left := container.NewVBox()
-	right := container.NewVBox()
-	for i := 0; i < len(g.leftLabels); i++ {
-		l := g.leftLabels[i]
-		// Put each label into a small card (white box) for readability
-		left.Add(widget.NewCard("", "", l))
+// relabel updates every text on the board after the theme or language changed
+func (g *Game) relabel() {
+	g.win.SetTitle(g.pack.Title)
+	if g.title != nil {
+		g.title.SetText(g.pack.Title)
+	}
+	for i, btn := range g.gridButtons {
+		btn.SetText(g.pack.TrayLabel(i + 1))
 	}
-	for i := 0; i < len(g.rightLabels); i++ {
-		l := g.rightLabels[i]
-		right.Add(widget.NewCard("", "", l))
+	if g.playerTrayButton != nil {
+		g.playerTrayButton.SetText(g.pack.TrayLabel(g.playerTray + 1))
 	}
+	if g.myTrayLabel != nil {
+		g.myTrayLabel.SetText(g.myTrayText())
+	}
+	g.refreshLabels()
+	g.refreshHistory()
+}
+
+// setupUI builds the board: sidebars, tray grid and offer history. onDrawer
+// hears whether some of it had to move into the drawer to fit the window.
+func (g *Game) setupUI(a fyne.App, onDrawer func(needed bool)) fyne.CanvasObject {
+	half := g.sidebarSplit()
+	left := sidebarColumn(g.sidebarCells[:half])
+	right := sidebarColumn(g.sidebarCells[half:])
 
-	g.gridButtons = make([]*widget.Button, NUM_TRAYS)
-	grid := container.NewGridWithColumns(6)
-	for i := 0; i < NUM_TRAYS; i++ {
+	g.gridButtons = make([]*widget.Button, g.numTrays)
+	grid := container.New(trayGridLayout{})
+	for i := 0; i < g.numTrays; i++ {
 		index := i
-		btn := widget.NewButton(fmt.Sprintf("🍽️ %d", i+1), func() {
+		btn := widget.NewButton(g.pack.TrayLabel(i+1), func() {
 			g.onTrayClicked(a, index)
 		})
 		btn.Importance = widget.HighImportance
@@ -223,8 +299,10 @@ func (g *Game) setupUI(a fyne.App) fyne.CanvasObject {
 		grid.Add(btn)
 	}
 
-	center := container.NewHBox(left, grid, right)
-	return center
+	if mobileUI {
+		return g.mobileBoard(grid)
+	}
+	return newBoardView(onDrawer, left, container.NewVScroll(grid), right, g.historyPanel())
 }
 
 func (g *Game) onTrayClicked(a fyne.App, idx int) {
@@ -232,140 +310,165 @@ func (g *Game) onTrayClicked(a fyne.App, idx int) {
 
 	// First pick → player's tray
 	if g.playerTray == -1 {
+		g.clock.Stop()
+		g.waiting = true
 		g.playerTray = idx
+		g.pickedTray = idx
+		g.showPlayerBoard(a)
+		g.changed()
 
-		// Create a visual representation of player's tray (same size as other trays)
-		g.playerTrayButton = widget.NewButton(fmt.Sprintf("🍽️ %d", idx+1), nil)
-		g.playerTrayButton.Importance = widget.HighImportance
-
-		// IMPORTANT: Disable the button BEFORE rebuilding UI
-		g.gridButtons[idx].Disable()
-
-		// bottom indicator with the tray button
-		bottom := container.NewCenter(
-			container.NewHBox(
-				widget.NewLabel("My Tray: "),
-				g.playerTrayButton,
-			),
-		)
-		content := g.setupUI(a)
-		// Make sure the disabled state persists
-		g.gridButtons[idx].Disable()
-
-		w.SetContent(container.NewBorder(
-			container.NewCenter(widget.NewLabel("🍽️ Meal or No Meal 🍽️")),
-			bottom,
-			nil,
-			nil,
-			container.NewCenter(content),
-		))
-
-		dialog.ShowInformation("Your Tray",
-			fmt.Sprintf("You chose Tray %d. This is your tray until the end!", idx+1), w)
+		d := dialog.NewInformation(T("tray.yours.title"), T("tray.yours.body", idx+1), w)
+		d.SetOnClosed(g.startPickClock)
+		d.Show()
 		return
 	}
 
-	// prevent re-opening player's tray
+	// prevent re-opening player's tray; the pick clock keeps running
 	if idx == g.playerTray {
-		dialog.ShowInformation("Not Allowed", "That's your tray! You can't open it yet.", w)
+		dialog.ShowInformation(T("tray.not_allowed.title"), T("tray.not_allowed.body"), w)
 		return
 	}
 
 	// open chosen tray
+	g.clock.Stop()
+	g.waiting = true
 	g.gridButtons[idx].Disable()
 	g.openedTraysCount++
+	g.openedOrder = append(g.openedOrder, idx)
+	g.openedValues[g.sidebarValue(idx)] = true
+	g.revealing = idx
+	g.changed()
+	g.verify("opening tray " + strconv.Itoa(idx+1))
 
 	// Show tray opened dialog with image
 	g.showTrayOpenedDialog(w, idx)
 }
 
+// showPlayerBoard shows the board again with the player's tray in a bar
+// below it
+func (g *Game) showPlayerBoard(a fyne.App) {
+	idx := g.playerTray
+
+	// Create a visual representation of player's tray (same size as other trays)
+	g.playerTrayButton = widget.NewButton(g.pack.TrayLabel(idx+1), nil)
+	g.playerTrayButton.Importance = widget.HighImportance
+
+	// IMPORTANT: Disable the button BEFORE rebuilding UI
+	if g.gridButtons != nil {
+		g.gridButtons[idx].Disable()
+	}
+
+	// bottom indicator with the tray button
+	g.myTrayLabel = widget.NewLabel(g.myTrayText())
+	bottom := container.NewCenter(
+		container.NewHBox(
+			g.myTrayLabel,
+			g.playerTrayButton,
+		),
+	)
+	g.showBoard(a, bottom)
+	// Make sure the disabled state persists
+	g.gridButtons[idx].Disable()
+}
+
 func (g *Game) showTrayOpenedDialog(parent fyne.Window, idx int) {
 	var contentWidget fyne.CanvasObject
+	startReveal := func() {}
+	// the sidebar strikes the value once the player can see it
+	revealed := func() {
+		if g.revealing == idx {
+			g.revealing = -1
+			g.changed()
+		}
+	}
 
 	if g.itemNames[idx] != "" {
 		// Show food item with cartoon image
-		foodImg := loadImage(fmt.Sprintf("%d.jpg", g.itemImages[idx]), 200, 200)
-		label := widget.NewLabel(fmt.Sprintf("🍽️ Tray %d contains:\n%s", idx+1, g.itemNames[idx]))
+		foodImg := loadImage(g.itemImages[idx], 200, 200)
+		var reveal fyne.CanvasObject
+		reveal, startReveal = newLidReveal(foodImg, scaled(200), revealed)
+		label := widget.NewLabel(T("tray.contains", g.pack.TrayIcon, idx+1, g.itemNames[idx]))
 		contentWidget = container.NewVBox(
-			container.NewCenter(foodImg),
+			container.NewCenter(reveal),
 			container.NewCenter(label),
 		)
 	} else {
-		// Show money value with corresponding image (1-26)
-		// Find which position this value is in the VALUES array
-		valueIndex := -1
-		for i, v := range VALUES {
-			if v == g.trayValues[idx] {
-				valueIndex = i + 1 // 1-based for image naming
-				break
-			}
-		}
-
-		if valueIndex > 0 {
-			moneyImg := loadImage(fmt.Sprintf("%d.jpg", valueIndex), 200, 200)
-			label := widget.NewLabel(fmt.Sprintf("🍽️ Tray %d contains:\n$%d", idx+1, g.trayValues[idx]))
-			contentWidget = container.NewVBox(
-				container.NewCenter(moneyImg),
-				container.NewCenter(label),
-			)
-		} else {
-			// Fallback if image not found
-			contentWidget = widget.NewLabel(fmt.Sprintf("🍽️ Tray %d contains:\n$%d", idx+1, g.trayValues[idx]))
-		}
+		// Show money value with corresponding image
+		moneyImg := loadImage(valueAsset(g.trayValues[idx]), 200, 200)
+		var reveal fyne.CanvasObject
+		reveal, startReveal = newLidReveal(moneyImg, scaled(200), revealed)
+		label := widget.NewLabel(T("tray.contains", g.pack.TrayIcon, idx+1, Money(g.trayValues[idx])))
+		contentWidget = container.NewVBox(
+			container.NewCenter(reveal),
+			container.NewCenter(label),
+		)
 	}
 
-	d := dialog.NewCustom("Tray Opened", "OK", contentWidget, parent)
+	g.sound.TrayOpened(g.trayValues[idx])
+
+	d := dialog.NewCustom(T("tray.opened.title"), T("ok"), contentWidget, parent)
 	d.SetOnClosed(func() {
-		// mark sidebar
-		g.markPriceAsOpened(idx)
+		revealed()
+		if g.itemNames[idx] != "" && g.allFoodOpened() {
+			g.achieve(GameEvent{Kind: EventAllFood})
+		}
 
-		// Check if it's time for chef offer (every 3 trays)
-		if g.openedTraysCount%3 == 0 || g.getUnopenedCount() == 1 {
+		// Check if it's time for chef offer (every 3 trays by default)
+		if g.offerDue() {
 			g.showChefOffer(parent)
+		} else {
+			g.startPickClock()
 		}
 	})
 	d.Show()
+	startReveal()
 }
 
-func (g *Game) markPriceAsOpened(trayIndex int) {
-	// If this tray originally had an item, mark the removed numeric value slot
-	if g.trayReplaced[trayIndex] != -1 {
-		removed := g.trayReplaced[trayIndex]
-		g.openedValues[removed] = true // track it
-		for i := 0; i < len(VALUES); i++ {
-			if VALUES[i] == removed {
-				if i < len(VALUES)/2 {
-					lbl := g.leftLabels[i]
-					lbl.SetText("✓ " + lbl.Text)
-				} else {
-					lbl := g.rightLabels[i-len(VALUES)/2]
-					lbl.SetText("✓ " + lbl.Text)
-				}
-				return
-			}
+// allFoodOpened is true when every food tray besides the player's is open
+func (g *Game) allFoodOpened() bool {
+	for i := 0; i < g.numTrays; i++ {
+		if i != g.playerTray && g.itemNames[i] != "" && !g.gridButtons[i].Disabled() {
+			return false
 		}
 	}
+	return true
+}
 
-	// Otherwise find the matching numeric label and mark it
-	val := g.trayValues[trayIndex]
-	g.openedValues[val] = true // track it
-	for i := 0; i < len(VALUES); i++ {
-		if VALUES[i] == val {
-			if i < len(VALUES)/2 {
-				lbl := g.leftLabels[i]
-				lbl.SetText("✓ " + lbl.Text)
-			} else {
-				lbl := g.rightLabels[i-len(VALUES)/2]
-				lbl.SetText("✓ " + lbl.Text)
-			}
-			return
-		}
+// lockBoard disables every tray once the game is over
+func (g *Game) lockBoard() {
+	g.finished = true
+	for _, b := range g.gridButtons {
+		b.Disable()
+	}
+	g.changed()
+}
+
+// Label next to the player's tray, with their name if they gave one
+func (g *Game) myTrayText() string {
+	if g.config.PlayerName == "" {
+		return T("board.my_tray")
+	}
+	return T("board.player_tray", g.config.PlayerName)
+}
+
+// sidebarSplit is how many values go in the left sidebar
+func (g *Game) sidebarSplit() int {
+	return (len(g.values) + 1) / 2
+}
+
+// offerDue tells whether the Chef calls now: after every config.OfferEvery
+// trays, and when only one tray is left besides the player's
+func (g *Game) offerDue() bool {
+	every := g.config.OfferEvery
+	if every < 1 {
+		every = 3
 	}
+	return g.openedTraysCount%every == 0 || g.getUnopenedCount() == 1
 }
 
 func (g *Game) getUnopenedCount() int {
 	count := 0
-	for i := 0; i < NUM_TRAYS; i++ {
+	for i := 0; i < g.numTrays; i++ {
 		if i == g.playerTray {
 			continue // Don't count player's tray
 		}
@@ -377,176 +480,135 @@ func (g *Game) getUnopenedCount() int {
 }
 
 func (g *Game) showChefOffer(parent fyne.Window) {
-	remaining := []int{}
-	// Include player's tray in remaining values
-	for i := 0; i < NUM_TRAYS; i++ {
-		// skip opened (disabled) trays, but include player's tray
-		if i != g.playerTray && g.gridButtons[i].Disabled() {
-			continue
-		}
-		// only include numeric values (skip items)
-		if g.trayValues[i] != -1 {
-			remaining = append(remaining, g.trayValues[i])
-		}
-	}
+	remaining := g.remainingValues()
 	if len(remaining) == 0 {
+		g.offerResolved(parent)
+		return
+	}
+	if g.playingOut {
+		g.showWouldBeOffer(parent, remaining)
 		return
 	}
 
 	// Trigger bonuses ONLY ONCE per game at a random chef offer
-	// 30% chance to trigger bonus if not already offered
-	if !g.bonusOffered && g.chef.r.Float64() < 0.30 {
+	// (config.BonusChance, 30% by default) if not already offered
+	if !g.bonusOffered && g.chef.r.Float64() < g.config.BonusChance {
 		g.bonusOffered = true
 		// Show bonuses BEFORE chef offer
-		g.showBonusSequence(parent, remaining)
-		return
-	}
-
-	// Normal chef offer flow (no bonus)
-	if g.chef.OfferSwap() {
-		// Create buttons with symbols
-		acceptBtn := widget.NewButton("✓ Accept", nil)
-		declineBtn := widget.NewButton("✗ Decline", nil)
-
-		// Set colors: Accept = Blue, Decline = Grey
-		acceptBtn.Importance = widget.HighImportance    // Blue
-		declineBtn.Importance = widget.MediumImportance // Grey
-
-		content := widget.NewLabel("🍽️ The Banker offers to swap your tray with another unopened one. Swap?")
-		// Accept on LEFT, Decline on RIGHT
-		buttons := container.NewHBox(acceptBtn, declineBtn)
-		dialogContent := container.NewVBox(content, buttons)
-
-		dlg := dialog.NewCustomWithoutButtons("Banker's Offer", dialogContent, parent)
-
-		// Accept button = do the swap
-		acceptBtn.OnTapped = func() {
-			dlg.Hide()
-			g.swapTray(parent)
-		}
-
-		// Decline button = don't swap
-		declineBtn.OnTapped = func() {
-			dlg.Hide()
-			if g.getUnopenedCount() == 1 {
-				g.showFinalReveal(parent)
-			}
-		}
-
-		dlg.Show()
+		g.showBonusSequence(parent, func() {
+			g.makeOffer(parent, remaining)
+		})
 		return
 	}
 
-	offer := g.chef.CalculateOffer(remaining)
-
-	// Show the offer dialog
-	g.showOfferDialog(parent, offer)
-
+	g.makeOffer(parent, remaining)
 }
 
 // Show bonuses in sequence BEFORE chef offer
-func (g *Game) showBonusSequence(parent fyne.Window, remaining []int) {
+func (g *Game) showBonusSequence(parent fyne.Window, onDone func()) {
+	g.waiting = true
 	hasMultiplier := g.bonus.HasMultiplier()
 	hasAdditive := g.bonus.HasAdditive()
 
 	if hasMultiplier && hasAdditive {
 		// Show multiplier first, then additive, then chef offer
 		g.bonus.TriggerMultiplierWithCallback(parent, func() {
-			g.bonus.TriggerAdditiveWithCallback(parent, func() {
-				// After both bonuses, proceed with chef offer
-				g.proceedWithChefOffer(parent, remaining)
-			})
+			g.bonus.TriggerAdditiveWithCallback(parent, onDone)
 		})
 	} else if hasMultiplier {
-		// Show multiplier, then chef offer
-		g.bonus.TriggerMultiplierWithCallback(parent, func() {
-			g.proceedWithChefOffer(parent, remaining)
-		})
+		g.bonus.TriggerMultiplierWithCallback(parent, onDone)
 	} else if hasAdditive {
-		// Show additive, then chef offer
-		g.bonus.TriggerAdditiveWithCallback(parent, func() {
-			g.proceedWithChefOffer(parent, remaining)
-		})
+		g.bonus.TriggerAdditiveWithCallback(parent, onDone)
 	} else {
 		// No bonuses available, proceed directly
-		g.proceedWithChefOffer(parent, remaining)
+		onDone()
 	}
 }
 
-func (g *Game) proceedWithChefOffer(parent fyne.Window, remaining []int) {
-	// If chef proposes swap
-	if g.chef.OfferSwap() {
-		//g.showSwapOfferDialog(parent)
-		return
+// Show the original and bonus-modified offer side by side, then the offer itself
+func (g *Game) showBonusAppliedDialog(parent fyne.Window, o Offer) {
+	originalImg := loadImage(g.offerAsset(o.Base), 120, 120)
+	newImg := loadImage(g.offerAsset(o.Amount), 120, 120)
+
+	bonusContent := container.NewVBox(
+		widget.NewLabel(T("bonus.applied")),
+		widget.NewLabel(o.BonusDesc),
+		widget.NewSeparator(),
+		container.NewHBox(
+			container.NewVBox(
+				widget.NewLabel(T("offer.original")),
+				container.NewCenter(originalImg),
+				widget.NewLabel(Money(o.Base)),
+			),
+			widget.NewLabel("  →  "),
+			container.NewVBox(
+				widget.NewLabel(T("offer.new")),
+				container.NewCenter(newImg),
+				widget.NewLabel(Money(o.Amount)),
+			),
+		),
+	)
+
+	d := dialog.NewCustom(T("bonus.applied"), T("continue"), bonusContent, parent)
+	d.SetOnClosed(func() {
+		g.showOfferDialog(parent, o.Amount)
+	})
+	d.Show()
+}
+
+// Ask the player whether they want to swap trays
+func (g *Game) showSwapOfferDialog(parent fyne.Window) {
+	// Create buttons with symbols
+	acceptBtn := widget.NewButton(T("accept"), nil)
+	declineBtn := widget.NewButton(T("decline"), nil)
+
+	// Set colors: Accept = Blue, Decline = Grey
+	acceptBtn.Importance = widget.HighImportance    // Blue
+	declineBtn.Importance = widget.MediumImportance // Grey
+
+	content := widget.NewLabel(T("swap_offer.body", g.pack.TrayIcon))
+	// Accept on LEFT, Decline on RIGHT
+	buttons := container.NewHBox(touchButton(acceptBtn), touchButton(declineBtn))
+	dialogContent := container.NewVBox(content, buttons)
+
+	dlg := dialog.NewCustomWithoutButtons(T("swap_offer.title"), dialogContent, parent)
+
+	// Accept button = do the swap
+	acceptBtn.OnTapped = func() {
+		g.clock.Stop()
+		dlg.Hide()
+		g.respond(ResponseSwapped)
+		g.swapTray(parent)
 	}
 
-	offer := g.chef.CalculateOffer(remaining)
-
-	// Show bonus value being applied if there is one
-	if g.bonus.HasPendingBonus() {
-		originalOffer := offer
-		offer = g.bonus.Apply(offer)
-		bonusMsg := g.bonus.GetBonusDescription()
-
-		// Only show bonus dialog if there's actually a bonus description
-		if bonusMsg != "" {
-			// Show both original and new offer images
-			originalImgID := g.getOfferImageID(originalOffer)
-			newImgID := g.getOfferImageID(offer)
-
-			originalImg := loadImage(fmt.Sprintf("%d.jpg", originalImgID), 120, 120)
-			newImg := loadImage(fmt.Sprintf("%d.jpg", newImgID), 120, 120)
-
-			bonusContent := container.NewVBox(
-				widget.NewLabel(" Bonus Applied!"),
-				widget.NewLabel(bonusMsg),
-				widget.NewSeparator(),
-				container.NewHBox(
-					container.NewVBox(
-						widget.NewLabel("Original Offer:"),
-						container.NewCenter(originalImg),
-						widget.NewLabel(fmt.Sprintf("$%d", originalOffer)),
-					),
-					widget.NewLabel("  →  "),
-					container.NewVBox(
-						widget.NewLabel("New Offer:"),
-						container.NewCenter(newImg),
-						widget.NewLabel(fmt.Sprintf("$%d", offer)),
-					),
-				),
-			)
-
-			d := dialog.NewCustom("Bonus Applied!", "Continue", bonusContent, parent)
-			d.SetOnClosed(func() {
-				g.showOfferDialog(parent, offer)
-			})
-			d.Show()
-		} else {
-			// No bonus description, skip directly to offer
-			g.showOfferDialog(parent, offer)
-		}
-	} else {
-		g.showOfferDialog(parent, offer)
+	// Decline button = don't swap
+	declineBtn.OnTapped = func() {
+		g.clock.Stop()
+		dlg.Hide()
+		g.respond(ResponseKept)
+		g.offerResolved(parent)
 	}
+
+	dlg.Show()
+	g.startDecisionClock(declineBtn.OnTapped)
 }
 
 // Helper function to show offer dialog with custom buttons and chef image
 func (g *Game) showOfferDialog(parent fyne.Window, offer int) {
 	// Get random chef image
-	chefImgID := g.chef.GetRandomChefImage()
-	chefImg := loadImage(fmt.Sprintf("%d.jpg", chefImgID), 200, 200)
+	chefImg := loadImage(g.chef.GetRandomChefImage(), 200, 200)
 
 	// Create buttons with symbols
-	acceptBtn := widget.NewButton("✓ Accept", nil)
-	declineBtn := widget.NewButton("✗ Decline", nil)
+	acceptBtn := widget.NewButton(T("accept"), nil)
+	declineBtn := widget.NewButton(T("decline"), nil)
 
 	// Set colors: Accept = Blue, Decline = Grey
 	acceptBtn.Importance = widget.HighImportance    // Blue
 	declineBtn.Importance = widget.MediumImportance // Grey
 
-	content := widget.NewLabel(fmt.Sprintf("‍ The Chef offers you: $%d\nMeal or No Meal?", offer))
+	content := widget.NewLabel(T("offer.body", Money(offer)))
 	// Accept on LEFT, Decline on RIGHT
-	buttons := container.NewHBox(acceptBtn, declineBtn)
+	buttons := container.NewHBox(touchButton(acceptBtn), touchButton(declineBtn))
 
 	dialogContent := container.NewVBox(
 		container.NewCenter(chefImg),
@@ -555,128 +617,97 @@ func (g *Game) showOfferDialog(parent fyne.Window, offer int) {
 		buttons,
 	)
 
-	dlg := dialog.NewCustomWithoutButtons("Chef's Offer", dialogContent, parent)
+	dlg := dialog.NewCustomWithoutButtons(T("offer.title"), dialogContent, parent)
 
 	// Accept button = take the deal
 	acceptBtn.OnTapped = func() {
+		g.clock.Stop()
 		dlg.Hide()
+		g.respond(ResponseDeal)
 		g.showDealAccepted(parent, offer)
 	}
 
 	// Decline button = continue playing
 	declineBtn.OnTapped = func() {
+		g.clock.Stop()
 		dlg.Hide()
-		if g.getUnopenedCount() == 1 {
-			g.showFinalReveal(parent)
-		}
+		g.respond(ResponseNoDeal)
+		g.achieve(GameEvent{Kind: EventOfferDeclined, Amount: offer})
+		g.offerResolved(parent)
 	}
 
 	dlg.Show()
+	g.startDecisionClock(declineBtn.OnTapped)
 }
 
 func (g *Game) swapTray(parent fyne.Window) {
 	// build list of available unopened trays
 	options := []string{}
-	for i := 0; i < NUM_TRAYS; i++ {
+	for i := 0; i < g.numTrays; i++ {
 		if i != g.playerTray && !g.gridButtons[i].Disabled() {
 			options = append(options, fmt.Sprintf("%d", i+1))
 		}
 	}
 	if len(options) == 0 {
-		dialog.ShowInformation("Swap", "No unopened trays available to swap.", parent)
+		d := dialog.NewInformation(T("swap.none.title"), T("swap.none.body"), parent)
+		d.SetOnClosed(func() { g.offerResolved(parent) })
+		d.Show()
 		return
 	}
 
 	selectW := widget.NewSelect(options, func(s string) {})
-	selectW.PlaceHolder = "Choose tray number"
+	selectW.PlaceHolder = T("swap.placeholder")
 
-	swapBtn := widget.NewButton("🔄 Swap", nil)
+	swapBtn := widget.NewButton(T("swap.button"), nil)
 	swapBtn.Importance = widget.HighImportance // Blue button
 
 	dialogContent := container.NewVBox(
-		widget.NewLabel("Choose a tray to swap with:"),
+		widget.NewLabel(T("swap.choose")),
 		selectW,
 		container.NewCenter(swapBtn), // Center the button
 	)
 
-	dlg := dialog.NewCustomWithoutButtons("Swap Tray", dialogContent, parent)
+	dlg := dialog.NewCustomWithoutButtons(T("swap.title"), dialogContent, parent)
 
 	swapBtn.OnTapped = func() {
 		if selectW.Selected != "" {
 			chosen, _ := strconv.Atoi(selectW.Selected)
-			newIdx := chosen - 1
-			oldPlayerTray := g.playerTray
-
-			// swap trayValues, itemNames, trayReplaced
-			g.trayValues[oldPlayerTray], g.trayValues[newIdx] = g.trayValues[newIdx], g.trayValues[oldPlayerTray]
-			g.itemNames[oldPlayerTray], g.itemNames[newIdx] = g.itemNames[newIdx], g.itemNames[oldPlayerTray]
-			g.trayReplaced[oldPlayerTray], g.trayReplaced[newIdx] = g.trayReplaced[newIdx], g.trayReplaced[oldPlayerTray]
-
-			// Update player tray to new index
-			g.playerTray = newIdx
-
-			// Enable old tray in grid, disable new tray in grid
-			g.gridButtons[oldPlayerTray].Enable()
-			g.gridButtons[newIdx].Disable()
-
-			// Update player tray button display on the right
-			g.playerTrayButton.SetText(fmt.Sprintf("🍽️ %d", newIdx+1))
-
-			g.refreshLabels()
+			g.swapPlayerTray(chosen - 1)
 
 			dlg.Hide()
-			dialog.ShowInformation("Swap Completed",
-				fmt.Sprintf("You swapped to Tray %d", g.playerTray+1), parent)
+			d := dialog.NewInformation(T("swap.done.title"), T("swap.done.body", g.playerTray+1), parent)
+			d.SetOnClosed(func() { g.offerResolved(parent) })
+			d.Show()
 		}
 	}
 
 	dlg.Show()
 }
 
-func (g *Game) refreshLabels() {
-	half := len(VALUES) / 2
+// Move the player's selection to another unopened tray. The contents stay
+// where they are: the player gives up their tray and takes the other one.
+func (g *Game) swapPlayerTray(newIdx int) {
+	oldPlayerTray := g.playerTray
 
-	// Rebuild the display array to properly handle "FOOD ITEM" markers
-	display := make([]int, len(VALUES))
-	copy(display, VALUES)
+	// Update player tray to new index
+	g.playerTray = newIdx
 
-	// Mark replaced values with sentinel
-	removed := map[int]bool{}
-	for _, v := range g.trayReplaced {
-		if v != -1 {
-			removed[v] = true
-		}
-	}
-	for i := 0; i < len(display); i++ {
-		if removed[display[i]] {
-			display[i] = -999999 // sentinel for FOOD ITEM
-		}
-	}
+	// Enable old tray in grid, disable new tray in grid
+	g.gridButtons[oldPlayerTray].Enable()
+	g.gridButtons[newIdx].Disable()
 
-	for i := 0; i < half; i++ {
-		valLeft := display[i]
-		var textLeft string
-		if valLeft == -999999 {
-			textLeft = "🍽️ FOOD ITEM"
-		} else {
-			textLeft = fmt.Sprintf("$%d", valLeft)
-		}
-		if g.isValueOpened(VALUES[i]) { // check against original VALUES
-			textLeft = "✓ " + textLeft
-		}
-		g.leftLabels[i].SetText(textLeft)
+	// Update player tray button display on the right
+	g.playerTrayButton.SetText(g.pack.TrayLabel(newIdx + 1))
 
-		valRight := display[i+half]
-		var textRight string
-		if valRight == -999999 {
-			textRight = "🍽️ FOOD ITEM"
-		} else {
-			textRight = fmt.Sprintf("$%d", valRight)
-		}
-		if g.isValueOpened(VALUES[i+half]) { // check against original VALUES
-			textRight = "✓ " + textRight
-		}
-		g.rightLabels[i].SetText(textRight)
+	g.verify("swapping to tray " + strconv.Itoa(newIdx+1))
+	g.changed()
+}
+
+// refreshLabels rewrites every sidebar entry, for when the language or
+// theme pack changed
+func (g *Game) refreshLabels() {
+	for _, c := range g.sidebarCells {
+		c.update()
 	}
 }
 
@@ -687,90 +718,57 @@ func (g *Game) isValueOpened(val int) bool {
 	return g.openedValues[val]
 }
 
-func (g *Game) showFinalReveal(parent fyne.Window) {
-	var contentWidget fyne.CanvasObject
-	if g.itemNames[g.playerTray] != "" {
-		foodImg := loadImage(fmt.Sprintf("%d.jpg", g.itemImages[g.playerTray]), 200, 200)
-		contentWidget = container.NewVBox(
-			widget.NewLabel(fmt.Sprintf("🍽️ Your tray (Tray %d) contains: %s", g.playerTray+1, g.itemNames[g.playerTray])),
-			widget.NewSeparator(),
-			container.NewCenter(foodImg),
-		)
-
-	} else {
-		// Show money value with image
-		valueIndex := -1
-		for i, v := range VALUES {
-			if v == g.trayValues[g.playerTray] {
-				valueIndex = i + 1
-				break
-			}
-		}
-
-		if valueIndex > 0 {
-			moneyImg := loadImage(fmt.Sprintf("%d.jpg", valueIndex), 200, 200)
-			label := widget.NewLabel(fmt.Sprintf("Your tray (Tray %d) contained:\n$%d", g.playerTray+1, g.trayValues[g.playerTray]))
-			contentWidget = container.NewVBox(
-				widget.NewSeparator(),
-				container.NewCenter(label),
-				container.NewCenter(moneyImg),
-			)
-		}
-	}
-
-	d := dialog.NewCustom("Final Reveal", "OK", contentWidget, parent)
-	d.SetOnClosed(func() {
-		for _, b := range g.gridButtons {
-			b.Disable()
-		}
-		g.showPlayAgain(parent)
-	})
-	d.Show()
-}
-
 func (g *Game) showPlayAgain(parent fyne.Window) {
-	playAgainBtn := widget.NewButton("🔄 Play Again", func() {
-		// Start a fresh game
-		ng := NewGame()
-		ng.win = parent
-		ng.initialize()
-		content := ng.setupUI(fyne.CurrentApp())
-		parent.SetContent(container.NewBorder(
-			container.NewCenter(widget.NewLabel("🍽️ Meal or No Meal 🍽️")),
-			nil,
-			nil,
-			nil,
-			container.NewCenter(content),
-		))
-	})
-
-	closeBtn := widget.NewButton("❌ Close", func() {
-		// Close the window and quit the app
-		parent.Close()
-		fyne.CurrentApp().Quit()
+	g.clock.Stop()
+	playAgainBtn := widget.NewButton(T("game_over.play_again"), nil)
+	setupBtn := widget.NewButton(T("game_over.setup"), nil)
+	closeBtn := widget.NewButton(T("game_over.close"), nil)
+	exportBtn := widget.NewButton(T("results.export"), func() {
+		if results := LoadResults(fyne.CurrentApp().Preferences()); len(results) > 0 {
+			exportReport(parent, results[len(results)-1])
+		}
 	})
 
-	// Create buttons container
-	buttonsContainer := container.NewHBox(playAgainBtn, closeBtn)
+	// Create buttons container with the lifetime stats above it
+	stats := LoadStats(fyne.CurrentApp().Preferences())
+	buttonsContainer := container.NewVBox(
+		g.offerSummary(),
+		widget.NewSeparator(),
+		widget.NewLabel(T("game_over.difficulty", T("difficulty."+difficultyByName(g.config.Difficulty).Name))),
+		widget.NewLabel(stats.Summary()),
+		container.NewHBox(playAgainBtn, setupBtn, exportBtn, closeBtn),
+	)
 
 	// Create and show dialog, store reference so we can hide it
-	dlg := dialog.NewCustomWithoutButtons("🎮 Game Over", buttonsContainer, parent)
+	dlg := dialog.NewCustomWithoutButtons(T("game_over.title"), buttonsContainer, parent)
+
+	// The daily challenge is played once; offer the share string instead.
+	// Tournament games go back to the standings.
+	if g.tournamentGame != 0 {
+		playAgainBtn.SetText(T("tournament.standings"))
+		playAgainBtn.OnTapped = func() {
+			dlg.Hide()
+			showTournament(parent)
+		}
+	} else if g.daily != "" {
+		playAgainBtn.SetText(T("daily.share"))
+		playAgainBtn.OnTapped = func() {
+			dlg.Hide()
+			showLobby(parent)
+			showDailyDialog(parent)
+		}
+	} else {
+		// Start a fresh game with the same setup
+		playAgainBtn.OnTapped = func() {
+			dlg.Hide()
+			startGame(parent, g.config)
+		}
+	}
 
-	// Update play again button to hide dialog first
-	playAgainBtn.OnTapped = func() {
+	// Back to the start screen
+	setupBtn.OnTapped = func() {
 		dlg.Hide()
-		// Start a fresh game
-		ng := NewGame()
-		ng.win = parent
-		ng.initialize()
-		content := ng.setupUI(fyne.CurrentApp())
-		parent.SetContent(container.NewBorder(
-			container.NewCenter(widget.NewLabel("🍽️ Meal or No Meal 🍽️")),
-			nil,
-			nil,
-			nil,
-			container.NewCenter(content),
-		))
+		showLobby(parent)
 	}
 
 	closeBtn.OnTapped = func() {
@@ -784,17 +782,23 @@ func (g *Game) showPlayAgain(parent fyne.Window) {
 
 func (g *Game) showDealAccepted(parent fyne.Window, offer int) {
 	// Get random chef image for the accepted deal
-	chefImgID := g.chef.GetRandomChefImage()
-	chefImg := loadImage(fmt.Sprintf("%d.jpg", chefImgID), 200, 200)
+	chefImg := loadImage(g.chef.GetRandomChefImage(), 200, 200)
 
 	var contentWidget fyne.CanvasObject
+	playItOut := currentSettings().PlayItOut
 
-	if g.itemNames[g.playerTray] != "" {
+	if playItOut {
+		// Keep the tray closed, it is opened at the end of the play-out
+		contentWidget = container.NewVBox(
+			widget.NewLabel(T("deal.accepted", Money(offer))),
+			container.NewCenter(chefImg),
+		)
+	} else if g.itemNames[g.playerTray] != "" {
 		// Show food item with image
-		foodImg := loadImage(fmt.Sprintf("%d.jpg", g.itemImages[g.playerTray]), 200, 200)
-		label := widget.NewLabel(fmt.Sprintf(" Your tray (Tray %d) contained:\n%s", g.playerTray+1, g.itemNames[g.playerTray]))
+		foodImg := loadImage(g.itemImages[g.playerTray], 200, 200)
+		label := widget.NewLabel(T("deal.your_tray", g.playerTray+1, g.itemNames[g.playerTray]))
 		contentWidget = container.NewVBox(
-			widget.NewLabel(fmt.Sprintf("You accepted the deal!\n️  Your reward:  %d", offer)),
+			widget.NewLabel(T("deal.accepted", Money(offer))),
 			container.NewCenter(chefImg),
 			widget.NewSeparator(),
 			container.NewCenter(label),
@@ -802,59 +806,85 @@ func (g *Game) showDealAccepted(parent fyne.Window, offer int) {
 		)
 	} else {
 		// Show money value with image
-		valueIndex := -1
-		for i, v := range VALUES {
-			if v == g.trayValues[g.playerTray] {
-				valueIndex = i + 1
-				break
-			}
-		}
-
-		if valueIndex > 0 {
-			moneyImg := loadImage(fmt.Sprintf("%d.jpg", valueIndex), 200, 200)
-			label := widget.NewLabel(fmt.Sprintf("Your tray (Tray %d) contained:\n$%d", g.playerTray+1, g.trayValues[g.playerTray]))
-			contentWidget = container.NewVBox(
-				widget.NewLabel(fmt.Sprintf("You accepted the deal!\n️  Your reward:  %d", offer)),
-				container.NewCenter(chefImg),
-				widget.NewSeparator(),
-				container.NewCenter(label),
-				container.NewCenter(moneyImg),
-			)
-		} else {
-			contentWidget = container.NewVBox(
-				widget.NewLabel(fmt.Sprintf("You accepted the deal!\n️  Your reward:  %d", offer)),
-				container.NewCenter(chefImg),
-				widget.NewSeparator(),
-				widget.NewLabel(fmt.Sprintf("Your tray (Tray %d) contained:\n$%d", g.playerTray+1, g.trayValues[g.playerTray])),
-			)
-		}
+		moneyImg := loadImage(valueAsset(g.trayValues[g.playerTray]), 200, 200)
+		label := widget.NewLabel(T("deal.your_tray", g.playerTray+1, Money(g.trayValues[g.playerTray])))
+		contentWidget = container.NewVBox(
+			widget.NewLabel(T("deal.accepted", Money(offer))),
+			container.NewCenter(chefImg),
+			widget.NewSeparator(),
+			container.NewCenter(label),
+			container.NewCenter(moneyImg),
+		)
 	}
 
-	d := dialog.NewCustom("Game Over - Deal Accepted!", "OK", contentWidget, parent)
+	updateStats(func(s *Stats) { s.RecordDeal(offer) })
+	g.recordResult(true, offer)
+	g.sound.Play(CueDealAccepted)
+
+	d := dialog.NewCustom(T("deal.title"), T("ok"), contentWidget, parent)
 	d.SetOnClosed(func() {
-		for _, b := range g.gridButtons {
-			b.Disable()
+		if playItOut {
+			g.startPlayOut(parent, offer)
+			return
 		}
+		g.lockBoard()
 		g.showPlayAgain(parent)
 	})
 	d.Show()
 }
 
 func main() {
-	a := app.New()
-	w := a.NewWindow("🍽️ Meal or No Meal 🍽️")
-	g := NewGame()
-	g.win = w
-	g.initialize()
-
-	content := g.setupUI(a)
-	w.SetContent(container.NewBorder(
-		container.NewCenter(widget.NewLabel("🍽️ Meal or No Meal 🍽️")),
-		nil,
-		nil,
-		nil,
-		container.NewCenter(content),
-	))
-	w.Resize(fyne.NewSize(1000, 600))
+	opts, err := LoadOptions(os.Args[1:], os.Getenv, os.Stderr)
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	if opts.PrintConfig {
+		if err := opts.Print(os.Stdout); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+	runOptions = opts
+
+	a := app.NewWithID("com.galya777.mealnomeal")
+	applyLocale(currentSettings())
+	if dir := opts.Assets; dir != "" {
+		if store, err := NewAssetStore(dir); err != nil {
+			fmt.Fprintln(os.Stderr, "custom art not loaded:", err)
+		} else {
+			baseAssets, assets = store, store
+		}
+	}
+	if missing := assets.Missing(); len(missing) > 0 {
+		fmt.Fprintln(os.Stderr, "missing images:", missing)
+	}
+	if path := currentSettings().ThemePack; path != "" {
+		if p, err := LoadThemePack(path); err != nil {
+			fmt.Fprintln(os.Stderr, "theme pack not loaded:", err)
+		} else {
+			useThemePack(p)
+		}
+	}
+	mobileUI = fyne.CurrentDevice().IsMobile() || os.Getenv("MEALNOMEAL_MOBILE") != ""
+	w := a.NewWindow(currentPack.Title)
+	gameSound = NewSound(newAudioBackend())
+
+	showLobby(w)
+	w.SetMainMenu(newMainMenu(w))
+	gameSound.Apply(currentSettings())
+	// A phone may close the app once it is in the background
+	a.Lifecycle().SetOnExitedForeground(saveActiveGame)
+	a.Lifecycle().SetOnStopped(saveActiveGame)
+	w.Resize(fyne.NewSize(float32(opts.Width), float32(opts.Height)))
+	if currentSettings().TVMode {
+		applyTVMode(w, true)
+	} else if opts.Fullscreen {
+		w.SetFullScreen(true)
+	}
 	w.ShowAndRun()
 }
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"testing"
+)
+
+// TestMain checks the game state after every action in every test, and a
+// broken invariant fails the run
+func TestMain(m *testing.M) {
+	checkInvariants = true
+	invariantBroken = func(action string, err error) {
+		panic(fmt.Sprintf("game state broken after %s: %v", action, err))
+	}
+	os.Exit(m.Run())
+}
+
+func TestOfferAsset(t *testing.T) {
+	tests := []struct {
+		offer int
+		want  string
+	}{
+		{0, "value_1"},
+		{1, "value_1"},
+		{7, "value_5"},
+		{8, "value_10"},
+		{12000, "value_12500"},
+		{60000, "value_50000"},
+		{900000, "value_1000000"},
+		{5000000, "value_1000000"},
+	}
+	g := &Game{}
+	for _, tt := range tests {
+		if got := g.offerAsset(tt.offer); got != tt.want {
+			t.Errorf("offerAsset(%d) = %q, want %q", tt.offer, got, tt.want)
+		}
+	}
+}
+
+func TestBoardValues(t *testing.T) {
+	for _, n := range []int{6, 10, 13, 26} {
+		values := boardValues(n)
+		if len(values) != n {
+			t.Errorf("boardValues(%d) has %d values", n, len(values))
+		}
+		if values[0] != VALUES[0] || values[n-1] != VALUES[len(VALUES)-1] {
+			t.Errorf("boardValues(%d) = %v, want the lowest and highest kept", n, values)
+		}
+		for i := 1; i < n; i++ {
+			if values[i] <= values[i-1] {
+				t.Errorf("boardValues(%d) = %v, not increasing", n, values)
+				break
+			}
+		}
+	}
+}
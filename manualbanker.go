@@ -0,0 +1,150 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+// Manual banker mode: a second person plays the Chef in a window of their
+// own. When an offer is due they see what is left and type an offer or
+// propose a swap, which the contestant then gets as usual.
+
+// bankerWindow is the open banker's window, nil if there is none
+var bankerWindow fyne.Window
+
+// openBankerWindow shows the banker's window, opening it if needed
+func openBankerWindow() fyne.Window {
+	if bankerWindow == nil {
+		bankerWindow = fyne.CurrentApp().NewWindow(T("banker.title"))
+		bankerWindow.Resize(fyne.NewSize(480, 420))
+		bankerWindow.Show()
+	}
+	bankerWindow.SetOnClosed(func() { bankerWindow = nil })
+	bankerWindow.SetContent(container.NewCenter(widget.NewLabel(T("banker.idle"))))
+	return bankerWindow
+}
+
+// parseAmount reads an offer typed by the banker, like "12,500", "€800"
+// or "1 000 лв." as Money prints it. The thousands separator and the
+// decimal point are the current language's, so "800,50" is 800.50 in
+// Bulgarian. Offers are whole amounts: "800.00" is fine but "800.50" is
+// refused.
+func parseAmount(s string) (int, error) {
+	for _, c := range currencies {
+		s = strings.ReplaceAll(s, c.Symbol, "")
+	}
+	s = strings.ReplaceAll(s, "лв", "") // also without its dot
+	s = strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) {
+			return -1
+		}
+		return r
+	}, s)
+	if sep := strings.TrimSpace(T("number.thousands")); sep != "" {
+		s = strings.ReplaceAll(s, sep, "")
+	}
+	s, fraction, _ := strings.Cut(s, T("number.decimal"))
+	if strings.Trim(fraction, "0") != "" {
+		return 0, errors.New(T("banker.amount_whole"))
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil || v <= 0 {
+		return 0, errors.New(T("banker.amount_invalid"))
+	}
+	return v, nil
+}
+
+// askBanker has the banker make the next offer. The contestant waits with
+// a note that the Chef is thinking. If the banker's window is closed, the
+// computer Chef makes the offer instead.
+func (g *Game) askBanker(parent fyne.Window, remaining []int, present func(Offer)) {
+	thinking := dialog.NewCustomWithoutButtons(T("banker.thinking.title"), widget.NewLabel(T("banker.thinking.body")), parent)
+	done := false
+	finish := func(o Offer) {
+		if done {
+			return
+		}
+		done = true
+		thinking.Hide()
+		if bankerWindow != nil {
+			bankerWindow.SetContent(container.NewCenter(widget.NewLabel(T("banker.idle"))))
+		}
+		present(o)
+	}
+
+	w := openBankerWindow()
+	w.SetOnClosed(func() {
+		bankerWindow = nil
+		finish(g.buildOffer(remaining))
+	})
+
+	ev, max := g.expectedValue()
+	low, high := g.chef.OfferRange(remaining)
+	amount := widget.NewEntry()
+	amount.SetPlaceHolder(T("banker.amount_placeholder"))
+	amount.Validator = func(s string) error {
+		_, err := parseAmount(s)
+		return err
+	}
+	offer := widget.NewButton(T("banker.offer"), func() {
+		v, err := parseAmount(amount.Text)
+		if err != nil {
+			return
+		}
+		o := g.withBonus(v)
+		o.By = offerByBanker
+		finish(o)
+	})
+	offer.Importance = widget.HighImportance
+	swap := widget.NewButton(T("banker.swap"), func() {
+		finish(Offer{Kind: SwapOffer, Final: g.getUnopenedCount() == 1, By: offerByBanker})
+	})
+
+	w.SetContent(container.NewBorder(
+		container.NewVBox(
+			widget.NewLabelWithStyle(T("banker.round", len(g.offers)+1, g.getUnopenedCount()), fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+			widget.NewLabel(T("banker.ev", Money(ev), Money(max))),
+			widget.NewLabel(T("banker.suggest", Money(low), Money(high))),
+			widget.NewSeparator(),
+		),
+		container.NewVBox(
+			widget.NewSeparator(),
+			widget.NewForm(widget.NewFormItem(T("banker.amount"), amount)),
+			container.NewHBox(offer, swap),
+		),
+		nil, nil,
+		container.NewVScroll(g.bankerValues()),
+	))
+	w.RequestFocus()
+	thinking.Show()
+}
+
+// bankerValues lists the values still in play, highest first, and how many
+// food trays are left
+func (g *Game) bankerValues() fyne.CanvasObject {
+	values := g.remainingValues()
+	sort.Sort(sort.Reverse(sort.IntSlice(values)))
+	grid := container.NewGridWithColumns(3)
+	for _, v := range values {
+		grid.Add(widget.NewLabel(Money(v)))
+	}
+	food := 0
+	for i := 0; i < g.numTrays; i++ {
+		if g.itemNames[i] != "" && (i == g.playerTray || !g.gridButtons[i].Disabled()) {
+			food++
+		}
+	}
+	if food > 0 {
+		grid.Add(widget.NewLabel(fmt.Sprintf("%s × %d", g.pack.ItemLabel, food)))
+	}
+	return grid
+}
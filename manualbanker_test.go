@@ -0,0 +1,154 @@
+package main
+
+import (
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/test"
+	"fyne.io/fyne/v2/widget"
+)
+
+// newBankerGame starts a board where a person plays the Chef and plays it
+// up to the first offer
+func newBankerGame(t *testing.T) (*Game, fyne.Window) {
+	t.Helper()
+	cfg := testConfig()
+	cfg.ManualBanker = true
+	g, w := newTestGame(t, cfg)
+	t.Cleanup(func() {
+		if bankerWindow != nil {
+			bankerWindow.Close()
+		}
+	})
+	pickTray(t, g, w, 0)
+	openTrays(t, g, w, 3)
+	if bankerWindow == nil {
+		t.Fatal("no banker's window when the offer is due")
+	}
+	if !dialogHasText(w, T("banker.thinking.body")) {
+		t.Error("the contestant is not told the Chef is thinking")
+	}
+	return g, w
+}
+
+// bankerForm returns the amount entry and a button of the banker's window
+func bankerForm(t *testing.T, button string) (*widget.Entry, *widget.Button) {
+	t.Helper()
+	var entry *widget.Entry
+	var btn *widget.Button
+	for _, o := range test.LaidOutObjects(bankerWindow.Content()) {
+		switch o := o.(type) {
+		case *widget.Entry:
+			entry = o
+		case *widget.Button:
+			if o.Text == button {
+				btn = o
+			}
+		}
+	}
+	if entry == nil || btn == nil {
+		t.Fatalf("banker's window has no entry or %q button", button)
+	}
+	return entry, btn
+}
+
+func TestParseAmount(t *testing.T) {
+	defer func() { currentLang = "en" }()
+	tests := []struct {
+		lang string
+		in   string
+		want int
+		ok   bool
+	}{
+		{"en", "12500", 12500, true},
+		{"en", " 12,500 ", 12500, true},
+		{"en", "$800", 800, true},
+		{"en", "€1,000", 1000, true},
+		{"en", "12 500 лв.", 12500, true},
+		{"en", "12\u00a0500 лв", 12500, true},
+		{"en", "800.00", 800, true},
+		{"en", "$800.", 800, true},
+		{"en", "800.50", 0, false},
+		{"en", "1.500", 0, false},
+		{"en", "12,500.5", 0, false},
+		{"en", ".", 0, false},
+		{"en", "0", 0, false},
+		{"en", "-5", 0, false},
+		{"en", "lots", 0, false},
+		{"en", "", 0, false},
+		{"bg", "12 500 лв.", 12500, true},
+		{"bg", "800,00", 800, true},
+		{"bg", "800,50", 0, false},
+		{"bg", "12,500", 0, false},
+		{"bg", "12.500", 0, false},
+	}
+	for _, tt := range tests {
+		currentLang = tt.lang
+		got, err := parseAmount(tt.in)
+		if got != tt.want || (err == nil) != tt.ok {
+			t.Errorf("%s: parseAmount(%q) = %d, %v", tt.lang, tt.in, got, err)
+		}
+	}
+}
+
+func TestParseAmountReadsMoney(t *testing.T) {
+	defer func() { currentLang, currentCurrency = "en", "USD" }()
+	for _, lang := range []string{"en", "bg"} {
+		for _, c := range currencies {
+			currentLang, currentCurrency = lang, c.Code
+			for _, v := range []int{1, 800, 12500, 1000000} {
+				if got, err := parseAmount(Money(v)); got != v || err != nil {
+					t.Errorf("%s/%s: parseAmount(%q) = %d, %v", lang, c.Code, Money(v), got, err)
+				}
+			}
+		}
+	}
+}
+
+func TestBankerTypesOffer(t *testing.T) {
+	g, w := newBankerGame(t)
+
+	entry, offer := bankerForm(t, T("banker.offer"))
+	test.Type(entry, "nonsense")
+	test.Tap(offer)
+	if len(g.offers) != 0 {
+		t.Fatal("an offer was made from a bad amount")
+	}
+
+	entry.SetText("")
+	test.Type(entry, "12,345")
+	test.Tap(offer)
+	if len(g.offers) != 1 || g.offers[0].Amount != 12345 || g.offers[0].By != offerByBanker {
+		t.Fatalf("offers = %+v, want one of 12345 from the banker", g.offers)
+	}
+	if !dialogHasText(w, T("offer.body", Money(12345))) {
+		t.Error("the contestant did not get the banker's offer")
+	}
+	if dialogHasText(w, T("banker.thinking.body")) {
+		t.Error("still thinking after the offer was made")
+	}
+}
+
+func TestBankerProposesSwap(t *testing.T) {
+	g, w := newBankerGame(t)
+	_, swap := bankerForm(t, T("banker.swap"))
+	test.Tap(swap)
+	if len(g.offers) != 1 || g.offers[0].Kind != SwapOffer {
+		t.Fatalf("offers = %+v, want a swap", g.offers)
+	}
+	if findDialogButton(w, T("accept")) == nil {
+		t.Error("no swap offer for the contestant")
+	}
+}
+
+func TestClosedBankerWindowFallsBack(t *testing.T) {
+	g, w := newBankerGame(t)
+	g.chef.swapChance = 0
+	bankerWindow.Close()
+	if len(g.offers) != 1 || g.offers[0].Kind != CashOffer {
+		t.Fatalf("offers = %+v, want the computer Chef's offer", g.offers)
+	}
+	if findDialogButton(w, T("decline")) == nil {
+		t.Error("no offer for the contestant")
+	}
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"image/color"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+)
+
+// Mobile profile: bigger touch targets and a portrait board split into
+// pages that are swiped between. It is on for phones and tablets, and with
+// MEALNOMEAL_MOBILE set for trying it out on the desktop.
+var mobileUI bool
+
+// Smallest size of anything tapped in the mobile profile
+var touchTarget = fyne.NewSize(64, 52)
+
+// Text and padding are drawn this much bigger in the mobile profile
+const touchZoom = 1.2
+
+// A swipe has to move this far sideways to change the page
+const swipeDistance = 60
+
+// touchButton gives a button a finger-sized minimum in the mobile profile
+func touchButton(b *widget.Button) fyne.CanvasObject {
+	if !mobileUI {
+		return b
+	}
+	space := canvas.NewRectangle(color.Transparent)
+	space.SetMinSize(touchTarget)
+	return container.NewStack(space, b)
+}
+
+// swipePager shows one page at a time with tabs at the bottom. Swiping
+// left or right moves to the next or previous page.
+type swipePager struct {
+	widget.BaseWidget
+	tabs *container.AppTabs
+	dx   float32 // sideways distance of the swipe so far
+}
+
+func newSwipePager(pages ...*container.TabItem) *swipePager {
+	p := &swipePager{tabs: container.NewAppTabs(pages...)}
+	p.tabs.SetTabLocation(container.TabLocationBottom)
+	p.ExtendBaseWidget(p)
+	return p
+}
+
+func (p *swipePager) CreateRenderer() fyne.WidgetRenderer {
+	return widget.NewSimpleRenderer(p.tabs)
+}
+
+func (p *swipePager) Dragged(e *fyne.DragEvent) {
+	p.dx += e.Dragged.DX
+}
+
+func (p *swipePager) DragEnd() {
+	i := p.tabs.SelectedIndex()
+	switch {
+	case p.dx <= -swipeDistance && i < len(p.tabs.Items)-1:
+		p.tabs.SelectIndex(i + 1)
+	case p.dx >= swipeDistance && i > 0:
+		p.tabs.SelectIndex(i - 1)
+	}
+	p.dx = 0
+}
+
+// mobileBoard is the board for a phone held upright: the trays, the values
+// and the offers each get a page. The pages do not scroll so that a swipe
+// anywhere on them turns the page.
+func (g *Game) mobileBoard(grid fyne.CanvasObject) fyne.CanvasObject {
+	half := g.sidebarSplit()
+	values := container.NewGridWithColumns(2,
+		cardColumn(g.sidebarCells[:half]),
+		cardColumn(g.sidebarCells[half:]),
+	)
+	return newSwipePager(
+		container.NewTabItem(T("mobile.board"), grid),
+		container.NewTabItem(T("mobile.values"), values),
+		container.NewTabItem(T("mobile.offers"), g.historyPanel()),
+	)
+}
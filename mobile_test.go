@@ -0,0 +1,182 @@
+package main
+
+import (
+	"slices"
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/test"
+	"fyne.io/fyne/v2/widget"
+)
+
+// newMobileGame starts a board in the mobile profile on a phone-sized window
+func newMobileGame(t *testing.T) (*Game, fyne.Window) {
+	t.Helper()
+	mobileUI = true
+	t.Cleanup(func() { mobileUI = false })
+	g, w := newTestGame(t, testConfig())
+	w.Resize(fyne.NewSize(360, 740))
+	return g, w
+}
+
+func TestMobileBoardSwipes(t *testing.T) {
+	g, w := newMobileGame(t)
+	var pager *swipePager
+	for _, o := range test.LaidOutObjects(w.Content()) {
+		if p, ok := o.(*swipePager); ok {
+			pager = p
+		}
+	}
+	if pager == nil {
+		t.Fatal("no swipe pager on the mobile board")
+	}
+	pos := fyne.CurrentApp().Driver().AbsolutePositionForObject(g.gridButtons[0]).AddXY(5, 5)
+
+	tests := []struct {
+		name string
+		dx   float32
+		want int
+	}{
+		{"left to values", -100, 1},
+		{"too short", -20, 1},
+		{"left to offers", -100, 2},
+		{"past the last page", -100, 2},
+		{"right to values", 100, 1},
+	}
+	for _, tt := range tests {
+		test.Drag(w.Canvas(), pos, tt.dx, 0)
+		if got := pager.tabs.SelectedIndex(); got != tt.want {
+			t.Errorf("%s: page %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestMobileTouchTargets(t *testing.T) {
+	g, w := newMobileGame(t)
+	g.bonus.showBonusChoiceDialog(w, "cases", g.bonus.multiplierOptions(), func(string) {})
+	b := findDialogButton(w, T("bonus.case", 1))
+	if b == nil {
+		t.Fatal("no bonus case button")
+	}
+	if s := b.Size(); s.Width < touchTarget.Width || s.Height < touchTarget.Height {
+		t.Errorf("case button is %v, want at least %v", s, touchTarget)
+	}
+}
+
+func TestResumeSavedGame(t *testing.T) {
+	g, w := newTestGame(t, testConfig())
+	g.chef.swapChance = 0
+	pickTray(t, g, w, 2)
+	openTrays(t, g, w, 3) // the Chef's offer is on the table
+	saveActiveGame()
+
+	p := fyne.CurrentApp().Preferences()
+	saved := LoadSavedGame(p)
+	if saved == nil {
+		t.Fatal("game not saved")
+	}
+	w2 := test.NewWindow(nil)
+	w2.Resize(fyne.NewSize(1000, 600))
+	defer w2.Close()
+	r := resumeGame(w2, saved)
+
+	if r.playerTray != 2 || !slices.Equal(r.openedOrder, g.openedOrder) || !slices.Equal(r.trayValues, g.trayValues) {
+		t.Errorf("resumed tray %d opened %v, want tray 2 opened %v", r.playerTray, r.openedOrder, g.openedOrder)
+	}
+	if err := r.Invariants(); err != nil {
+		t.Error(err)
+	}
+	if findDialogButton(w2, T("accept")) == nil {
+		t.Error("the pending offer is not shown again")
+	}
+	if LoadSavedGame(p) != nil {
+		t.Error("the save should be used up once resumed")
+	}
+
+	// a finished game is not worth resuming
+	tapDialog(t, w2, T("accept"))
+	saveActiveGame()
+	if LoadSavedGame(p) != nil {
+		t.Error("a game with a result was saved")
+	}
+}
+
+func TestResumeKeepsBonusAndOffers(t *testing.T) {
+	// the game as played without a break
+	g, w := newTestGame(t, testConfig())
+	g.chef.swapChance = 0
+	pickTray(t, g, w, 2)
+	openTrays(t, g, w, 3)
+	tapDialog(t, w, T("decline"))
+	openTrays(t, g, w, 3)
+	want := g.offers[1].Amount
+
+	// the same board, put away after the first offer with a bonus picked
+	g, w = newTestGame(t, testConfig())
+	g.chef.swapChance = 0
+	pickTray(t, g, w, 2)
+	openTrays(t, g, w, 3)
+	tapDialog(t, w, T("decline"))
+	g.bonus.multiplierUsed, g.bonus.multiplier, g.bonus.multiplierCase = true, 2, "*2"
+	saveActiveGame()
+	r := resumeGame(w, LoadSavedGame(fyne.CurrentApp().Preferences()))
+	if r.bonus.multiplier != 2 || r.bonus.multiplierCase != "*2" || !r.bonus.multiplierUsed {
+		t.Fatalf("pending bonus lost: %v %q", r.bonus.multiplier, r.bonus.multiplierCase)
+	}
+	r.chef.swapChance = 0
+	openTrays(t, r, w, 3)
+	if len(r.offers) != 2 || r.offers[1].Base != want || r.offers[1].Amount != 2*want || r.offers[1].Multiplier != "*2" {
+		t.Errorf("second offer after resuming = %+v, want %d doubled", r.offers, want)
+	}
+}
+
+func TestNoResumeAfterGameOver(t *testing.T) {
+	_, w := newTestGame(t, testConfig())
+	p := fyne.CurrentApp().Preferences()
+	cfg := testConfig()
+	tour := NewTournament([]string{"Ana", "Bo"}, 1, "total", cfg)
+	tour.Save(p)
+
+	// the save of an earlier game is dropped when a tournament game starts
+	(&SavedGame{Config: cfg, PlayerTray: 1}).Save(p)
+	startTournamentGame(w, tour, "Ana", 1)
+	if LoadSavedGame(p) != nil {
+		t.Fatal("starting a tournament game kept an older save")
+	}
+	g := activeGame
+	g.chef.swapChance = 0
+	pickTray(t, g, w, 0)
+	openTrays(t, g, w, 3)
+	saveActiveGame() // the window lost focus mid-game
+	if LoadSavedGame(p) == nil {
+		t.Fatal("game not saved mid-game")
+	}
+
+	tapDialog(t, w, T("accept"))
+	if LoadSavedGame(p) != nil {
+		t.Error("the save outlived the game's result")
+	}
+	tapDialog(t, w, T("ok"))
+	saveActiveGame() // and again once the board is locked
+	if LoadSavedGame(p) != nil {
+		t.Error("a finished game was saved")
+	}
+	if got := len(LoadTournament(p).Results); got != 1 {
+		t.Errorf("tournament has %d results, want 1", got)
+	}
+
+	// so the start screen has nothing to resume
+	showLobby(w)
+	for _, o := range test.LaidOutObjects(w.Content()) {
+		if b, ok := o.(*widget.Button); ok && b.Text == T("lobby.resume") {
+			t.Error("the start screen offers to resume a finished game")
+		}
+	}
+
+	// the same goes for the daily challenge
+	(&SavedGame{Config: cfg, PlayerTray: 1}).Save(p)
+	startDaily(w)
+	if LoadSavedGame(p) != nil {
+		t.Error("starting the daily challenge kept an older save")
+	}
+}
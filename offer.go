@@ -0,0 +1,111 @@
+package main
+
+import "fyne.io/fyne/v2"
+
+// OfferKind tells what the Chef is putting on the table
+type OfferKind int
+
+const (
+	CashOffer OfferKind = iota
+	SwapOffer
+)
+
+// Offer is one Chef offer. It is built first and then presented, so the
+// cash, swap, bonus and final-tray cases all go through the same path.
+type Offer struct {
+	Kind       OfferKind
+	Base       int    // cash offer before any bonus
+	Amount     int    // cash offer shown to the player
+	BonusDesc  string // "" if no bonus changed the offer
+	Multiplier string // multiplier case applied, like "/5"
+	Final      bool   // only one unopened tray left besides the player's
+	By         string // who made it: "" for the computer Chef, offerByBanker or offerByHost
+}
+
+// People who can make an offer instead of the computer Chef
+const (
+	offerByBanker = "banker"
+	offerByHost   = "host"
+)
+
+// remainingValues returns the numeric values still in play, player's tray included
+func (g *Game) remainingValues() []int {
+	remaining := []int{}
+	for i := 0; i < g.numTrays; i++ {
+		// skip opened (disabled) trays, but include player's tray
+		if i != g.playerTray && g.gridButtons[i].Disabled() {
+			continue
+		}
+		// only include numeric values (skip items)
+		if g.trayValues[i] != -1 {
+			remaining = append(remaining, g.trayValues[i])
+		}
+	}
+	return remaining
+}
+
+// buildOffer decides what the Chef offers and applies any pending bonus
+func (g *Game) buildOffer(remaining []int) Offer {
+	final := g.getUnopenedCount() == 1
+
+	if g.chef.OfferSwap() {
+		return Offer{Kind: SwapOffer, Final: final}
+	}
+
+	return g.cashOffer(remaining)
+}
+
+// cashOffer is the Chef's cash offer with any pending bonus applied
+func (g *Game) cashOffer(remaining []int) Offer {
+	return g.withBonus(g.chef.CalculateOffer(remaining))
+}
+
+// withBonus is a cash offer of base with any pending bonus applied
+func (g *Game) withBonus(base int) Offer {
+	o := Offer{Kind: CashOffer, Base: base, Amount: base, Final: g.getUnopenedCount() == 1}
+	if g.bonus.HasPendingBonus() {
+		o.BonusDesc = g.bonus.GetBonusDescription()
+		o.Multiplier = g.bonus.multiplierCase
+		o.Amount = g.bonus.Apply(base)
+	}
+	return o
+}
+
+// makeOffer has the Chef decide on an offer and presents it. In manual
+// banker mode a person decides instead of the computer.
+func (g *Game) makeOffer(parent fyne.Window, remaining []int) {
+	if g.config.ManualBanker {
+		g.askBanker(parent, remaining, func(o Offer) { g.presentOffer(parent, o) })
+		return
+	}
+	g.presentOffer(parent, g.buildOffer(remaining))
+}
+
+// presentOffer shows the right dialog for an offer
+func (g *Game) presentOffer(parent fyne.Window, o Offer) {
+	g.waiting = true
+	g.sound.Play(CuePhoneRing)
+	g.recordOffer(o)
+	switch {
+	case o.Kind == SwapOffer:
+		g.showSwapOfferDialog(parent)
+	case o.BonusDesc != "":
+		g.showBonusAppliedDialog(parent, o)
+	default:
+		g.showOfferDialog(parent, o.Amount)
+	}
+}
+
+// offerResolved continues the game after the player turned an offer down
+// or finished a swap. With two trays left it moves on to the final phase.
+func (g *Game) offerResolved(parent fyne.Window) {
+	if g.getUnopenedCount() == 1 {
+		if g.playingOut {
+			g.showPlayOutResult(parent)
+			return
+		}
+		g.showFinalDecision(parent)
+		return
+	}
+	g.startPickClock()
+}
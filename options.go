@@ -0,0 +1,259 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+)
+
+// Options are the settings for one run of the game. They come from the
+// command line, MEALNOMEAL_* environment variables and a TOML config file:
+// flags win over environment variables, which win over the file, which
+// wins over the defaults. Zero values leave the choice to the start screen
+// and the menus.
+type Options struct {
+	Width      float64       `toml:"width"`
+	Height     float64       `toml:"height"`
+	Fullscreen bool          `toml:"fullscreen"`
+	Seed       int64         `toml:"seed"`        // 0 = as set on the start screen
+	Assets     string        `toml:"assets"`      // directory with custom art
+	Language   string        `toml:"language"`    // "en", "bg" or "" = as set in the Language menu
+	Sound      string        `toml:"sound"`       // "on", "off" or "" = as set in the Sound menu
+	Volume     int           `toml:"volume"`      // 1-100, 0 = as set in the Sound menu
+	Trays      int           `toml:"trays"`       // 0 = as set on the start screen
+	OfferEvery int           `toml:"offer_every"` // the Chef calls after every this many trays
+	Chef       ChefOverrides `toml:"chef"`
+
+	Config      string `toml:"-"` // the file the rest was read from
+	PrintConfig bool   `toml:"-"`
+}
+
+// ChefOverrides replaces parts of the difficulty preset's Chef. Fields left
+// nil keep the preset's value.
+type ChefOverrides struct {
+	MinFactor  *float64 `toml:"min_factor" json:"minFactor,omitempty"`
+	MaxFactor  *float64 `toml:"max_factor" json:"maxFactor,omitempty"`
+	SwapChance *float64 `toml:"swap_chance" json:"swapChance,omitempty"`
+}
+
+// runOptions are the options the game was started with
+var runOptions = DefaultOptions()
+
+func DefaultOptions() Options {
+	return Options{
+		Width:      float64(defaultWindowSize.Width),
+		Height:     float64(defaultWindowSize.Height),
+		OfferEvery: 3,
+	}
+}
+
+// envName is the environment variable for a flag, like MEALNOMEAL_OFFER_EVERY
+func envName(flagName string) string {
+	return "MEALNOMEAL_" + strings.ToUpper(strings.ReplaceAll(flagName, "-", "_"))
+}
+
+// optionalFloat sets a float that may be left out
+func optionalFloat(p **float64) func(string) error {
+	return func(s string) error {
+		v, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return err
+		}
+		*p = &v
+		return nil
+	}
+}
+
+// flagSet has a flag for every option, writing into o
+func (o *Options) flagSet() *flag.FlagSet {
+	f := flag.NewFlagSet("mealnomeal", flag.ContinueOnError)
+	f.Float64Var(&o.Width, "width", o.Width, "window width")
+	f.Float64Var(&o.Height, "height", o.Height, "window height")
+	f.BoolVar(&o.Fullscreen, "fullscreen", o.Fullscreen, "start full screen")
+	f.Int64Var(&o.Seed, "seed", o.Seed, "seed for a repeatable board (0 = as on the start screen)")
+	f.StringVar(&o.Assets, "assets", o.Assets, "directory with custom art")
+	f.StringVar(&o.Language, "language", o.Language, "language: en or bg")
+	f.StringVar(&o.Sound, "sound", o.Sound, "sound: on or off")
+	f.IntVar(&o.Volume, "volume", o.Volume, "sound volume 1-100")
+	f.IntVar(&o.Trays, "trays", o.Trays, fmt.Sprintf("number of trays, 6-%d", NUM_TRAYS))
+	f.IntVar(&o.OfferEvery, "offer-every", o.OfferEvery, "the Chef calls after every this many trays")
+	f.Func("chef-min-factor", "lowest Chef offer as a share of the average", optionalFloat(&o.Chef.MinFactor))
+	f.Func("chef-max-factor", "highest Chef offer as a share of the average", optionalFloat(&o.Chef.MaxFactor))
+	f.Func("chef-swap-chance", "chance an offer is a swap, 0-1", optionalFloat(&o.Chef.SwapChance))
+	f.StringVar(&o.Config, "config", o.Config, "TOML config file (default: mealnomeal/config.toml in the user config directory)")
+	f.BoolVar(&o.PrintConfig, "print-config", o.PrintConfig, "print the settings in effect as TOML and quit")
+	return f
+}
+
+// defaultConfigPath is where the config file is looked for when none is given
+func defaultConfigPath() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "mealnomeal", "config.toml")
+}
+
+// LoadOptions works out the options from the command line args, the
+// environment as seen through getenv and the config file. Usage goes to
+// out when asked for or when a flag is wrong.
+func LoadOptions(args []string, getenv func(string) string, out io.Writer) (Options, error) {
+	o := DefaultOptions()
+
+	// the config file is read first, so find out which one it is
+	first := DefaultOptions()
+	scan := first.flagSet()
+	scan.SetOutput(out)
+	if err := scan.Parse(args); err != nil {
+		return o, err
+	}
+	path := first.Config
+	if path == "" {
+		path = getenv(envName("config"))
+	}
+	given := path != ""
+	if !given {
+		path = defaultConfigPath()
+	}
+	if path != "" {
+		md, err := toml.DecodeFile(path, &o)
+		switch {
+		case errors.Is(err, fs.ErrNotExist) && !given:
+		case err != nil:
+			return o, fmt.Errorf("config file %s: %w", path, err)
+		case len(md.Undecoded()) > 0:
+			return o, fmt.Errorf("config file %s: unknown setting %s", path, md.Undecoded()[0])
+		}
+	}
+
+	// then the environment, then the flags on top
+	flags := o.flagSet()
+	flags.SetOutput(io.Discard)
+	var err error
+	flags.VisitAll(func(f *flag.Flag) {
+		if v := getenv(envName(f.Name)); v != "" && err == nil {
+			if e := f.Value.Set(v); e != nil {
+				err = fmt.Errorf("%s: %w", envName(f.Name), e)
+			}
+		}
+	})
+	if err != nil {
+		return o, err
+	}
+	if err := flags.Parse(args); err != nil {
+		return o, err
+	}
+	o.Config = path
+	return o, o.validate()
+}
+
+// validate checks the options make sense together
+func (o Options) validate() error {
+	var errs []error
+	if o.Width < 320 || o.Height < 240 {
+		errs = append(errs, fmt.Errorf("window size %gx%g is below 320x240", o.Width, o.Height))
+	}
+	if o.Trays != 0 && (o.Trays < 6 || o.Trays > NUM_TRAYS) {
+		errs = append(errs, fmt.Errorf("trays must be 6-%d, not %d", NUM_TRAYS, o.Trays))
+	}
+	if o.OfferEvery < 1 {
+		errs = append(errs, fmt.Errorf("offer_every must be at least 1, not %d", o.OfferEvery))
+	}
+	if o.Sound != "" && o.Sound != "on" && o.Sound != "off" {
+		errs = append(errs, fmt.Errorf("sound must be on or off, not %q", o.Sound))
+	}
+	if o.Volume < 0 || o.Volume > 100 {
+		errs = append(errs, fmt.Errorf("volume must be 1-100, not %d", o.Volume))
+	}
+	if o.Language != "" {
+		known := false
+		for _, l := range languages {
+			known = known || l.Code == o.Language
+		}
+		if !known {
+			errs = append(errs, fmt.Errorf("unknown language %q", o.Language))
+		}
+	}
+	c := o.Chef
+	if c.MinFactor != nil && c.MaxFactor != nil && *c.MinFactor > *c.MaxFactor {
+		errs = append(errs, errors.New("chef min_factor is above max_factor"))
+	}
+	for _, f := range []*float64{c.MinFactor, c.MaxFactor} {
+		if f != nil && *f <= 0 {
+			errs = append(errs, fmt.Errorf("chef factors must be above 0, not %g", *f))
+		}
+	}
+	if c.SwapChance != nil && (*c.SwapChance < 0 || *c.SwapChance > 1) {
+		errs = append(errs, fmt.Errorf("chef swap_chance must be 0-1, not %g", *c.SwapChance))
+	}
+	return errors.Join(errs...)
+}
+
+// Print writes the options as a TOML config file
+func (o Options) Print(w io.Writer) error {
+	fmt.Fprintf(w, "# Meal or No Meal settings in effect (config file: %s)\n", o.Config)
+	return toml.NewEncoder(w).Encode(o)
+}
+
+// overrideSettings puts the language and sound options over s. They are
+// for this run only and never saved.
+func (o Options) overrideSettings(s *Settings) {
+	if o.Language != "" {
+		s.Language = o.Language
+	}
+	if o.Sound != "" {
+		s.Muted = o.Sound == "off"
+	}
+	if o.Volume != 0 {
+		s.Volume = float64(o.Volume) / 100
+	}
+}
+
+// keepSaved undoes overrideSettings on s before it is saved: a setting
+// that still has the option's value goes back to the saved one. A setting
+// changed in the menus since before is saved and drops the option for the
+// rest of the run.
+func (o *Options) keepSaved(s *Settings, before, saved Settings) {
+	if o.Language != "" {
+		if s.Language == before.Language {
+			s.Language = saved.Language
+		} else {
+			o.Language = ""
+		}
+	}
+	if o.Sound != "" {
+		if s.Muted == before.Muted {
+			s.Muted = saved.Muted
+		} else {
+			o.Sound = ""
+		}
+	}
+	if o.Volume != 0 {
+		if s.Volume == before.Volume {
+			s.Volume = saved.Volume
+		} else {
+			o.Volume = 0
+		}
+	}
+}
+
+// Game puts the options that shape a game over cfg
+func (o Options) Game(cfg GameConfig) GameConfig {
+	if o.Seed != 0 {
+		cfg.Seed = o.Seed
+	}
+	if o.Trays != 0 {
+		cfg.NumTrays = o.Trays
+	}
+	cfg.OfferEvery = o.OfferEvery
+	cfg.Chef = o.Chef
+	return cfg
+}
@@ -0,0 +1,276 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/test"
+	"fyne.io/fyne/v2/widget"
+	"github.com/BurntSushi/toml"
+)
+
+// writeConfig writes a config file to a temp dir and returns its path
+func writeConfig(t *testing.T, text string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.toml")
+	if err := os.WriteFile(path, []byte(text), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// envOf is a getenv backed by a map
+func envOf(env map[string]string) func(string) string {
+	return func(k string) string { return env[k] }
+}
+
+func TestLoadOptionsPrecedence(t *testing.T) {
+	path := writeConfig(t, `
+width = 1200
+trays = 10
+offer_every = 4
+language = "bg"
+
+[chef]
+min_factor = 0.4
+`)
+	tests := []struct {
+		name  string
+		env   map[string]string
+		args  []string
+		check func(Options) bool
+	}{
+		{"file over defaults", nil, nil, func(o Options) bool {
+			return o.Width == 1200 && o.Height == 600 && o.Trays == 10 && o.OfferEvery == 4 && *o.Chef.MinFactor == 0.4
+		}},
+		{"env over file", map[string]string{"MEALNOMEAL_TRAYS": "12", "MEALNOMEAL_CHEF_MIN_FACTOR": "0.5"}, nil, func(o Options) bool {
+			return o.Trays == 12 && *o.Chef.MinFactor == 0.5 && o.Width == 1200
+		}},
+		{"flag over env", map[string]string{"MEALNOMEAL_TRAYS": "12"}, []string{"--trays", "8", "-language=en"}, func(o Options) bool {
+			return o.Trays == 8 && o.Language == "en" && o.OfferEvery == 4
+		}},
+		{"unset chef parts stay nil", nil, []string{"--chef-swap-chance", "0"}, func(o Options) bool {
+			return o.Chef.MaxFactor == nil && *o.Chef.SwapChance == 0
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args := append([]string{"--config", path}, tt.args...)
+			o, err := LoadOptions(args, envOf(tt.env), io.Discard)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !tt.check(o) {
+				t.Errorf("options = %+v", o)
+			}
+		})
+	}
+}
+
+func TestLoadOptionsConfigFromEnv(t *testing.T) {
+	path := writeConfig(t, "seed = 77\n")
+	o, err := LoadOptions(nil, envOf(map[string]string{"MEALNOMEAL_CONFIG": path}), io.Discard)
+	if err != nil || o.Seed != 77 || o.Config != path {
+		t.Errorf("LoadOptions = %+v, %v", o, err)
+	}
+}
+
+func TestLoadOptionsErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		config string
+		env    map[string]string
+		args   []string
+		want   string
+	}{
+		{"unknown setting", "colour = \"red\"\n", nil, nil, "unknown setting colour"},
+		{"bad toml", "width = \n", nil, nil, "config file"},
+		{"bad env", "", map[string]string{"MEALNOMEAL_WIDTH": "wide"}, nil, "MEALNOMEAL_WIDTH"},
+		{"bad flag", "", nil, []string{"--trays", "many"}, "invalid value"},
+		{"too many trays", "", nil, []string{"--trays", "30"}, "trays must be"},
+		{"offer every zero", "offer_every = 0\n", nil, nil, "offer_every"},
+		{"sound", "", nil, []string{"--sound", "loud"}, "sound must be"},
+		{"language", "", nil, []string{"--language", "fr"}, "unknown language"},
+		{"chef factors", "[chef]\nmin_factor = 0.9\nmax_factor = 0.5\n", nil, nil, "above max_factor"},
+		{"swap chance", "", nil, []string{"--chef-swap-chance", "2"}, "swap_chance"},
+		{"tiny window", "", nil, []string{"--width", "100"}, "window size"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args := append([]string{"--config", writeConfig(t, tt.config)}, tt.args...)
+			_, err := LoadOptions(args, envOf(tt.env), io.Discard)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("error = %v, want one about %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadOptionsMissingFile(t *testing.T) {
+	// the default file may be missing, a file asked for may not
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("HOME", t.TempDir())
+	if o, err := LoadOptions(nil, envOf(nil), io.Discard); err != nil || o.OfferEvery != 3 {
+		t.Errorf("without a config file: %+v, %v", o, err)
+	}
+	missing := filepath.Join(t.TempDir(), "nope.toml")
+	if _, err := LoadOptions([]string{"--config", missing}, envOf(nil), io.Discard); err == nil {
+		t.Error("a missing --config file should be an error")
+	}
+}
+
+func TestLoadOptionsHelp(t *testing.T) {
+	var out bytes.Buffer
+	_, err := LoadOptions([]string{"-h"}, envOf(nil), &out)
+	if !errors.Is(err, flag.ErrHelp) || !strings.Contains(out.String(), "-print-config") {
+		t.Errorf("-h gave %v and %q", err, out.String())
+	}
+}
+
+func TestPrintConfigRoundTrips(t *testing.T) {
+	path := writeConfig(t, "fullscreen = true\nsound = \"off\"\n\n[chef]\nswap_chance = 0.25\n")
+	o, err := LoadOptions([]string{"--config", path, "--print-config", "--trays", "9"}, envOf(nil), io.Discard)
+	if err != nil || !o.PrintConfig {
+		t.Fatalf("LoadOptions = %+v, %v", o, err)
+	}
+	var buf bytes.Buffer
+	if err := o.Print(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), path) {
+		t.Errorf("printed config does not name the file read:\n%s", buf.String())
+	}
+
+	// what is printed loads back to the same options
+	again, err := LoadOptions([]string{"--config", writeConfig(t, buf.String())}, envOf(nil), io.Discard)
+	if err != nil {
+		t.Fatalf("printed config does not load: %v\n%s", err, buf.String())
+	}
+	if !again.Fullscreen || again.Sound != "off" || again.Trays != 9 || *again.Chef.SwapChance != 0.25 || again.Chef.MinFactor != nil {
+		t.Errorf("round trip gave %+v", again)
+	}
+	if _, err := toml.Decode(buf.String(), &Options{}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestOptionsGame(t *testing.T) {
+	high := 0.9
+	o := DefaultOptions()
+	cfg := DefaultGameConfig()
+	cfg.Seed, cfg.NumTrays = 5, 20
+	if got := o.Game(cfg); got.Seed != 5 || got.NumTrays != 20 || got.OfferEvery != 3 {
+		t.Errorf("default options changed the game: %+v", got)
+	}
+	o.Seed, o.Trays, o.OfferEvery, o.Chef.MaxFactor = 9, 8, 2, &high
+	got := o.Game(cfg)
+	if got.Seed != 9 || got.NumTrays != 8 || got.OfferEvery != 2 || got.Chef.MaxFactor != &high {
+		t.Errorf("Game() = %+v", got)
+	}
+
+	g := NewGame(got)
+	if g.chef.maxFactor != 0.9 {
+		t.Errorf("Chef max factor = %v, want 0.9", g.chef.maxFactor)
+	}
+}
+
+func TestSettingsOptionsAreNotSaved(t *testing.T) {
+	test.NewTempApp(t)
+	defer func() { runOptions = DefaultOptions() }()
+	runOptions.Language, runOptions.Sound, runOptions.Volume = "bg", "off", 40
+	prefs := fyne.CurrentApp().Preferences()
+
+	s := currentSettings()
+	if s.Language != "bg" || !s.Muted || s.Volume != 0.4 {
+		t.Errorf("the options are not in effect: %+v", s)
+	}
+	updateSettings(func(s *Settings) { s.ReduceMotion = true })
+	if saved := LoadSettings(prefs); saved.Language != "en" || saved.Muted || saved.Volume != 0.8 || !saved.ReduceMotion {
+		t.Errorf("the options were saved: %+v", saved)
+	}
+
+	// unmuting in the menu is saved and wins over --sound for the rest of the run
+	updateSettings(func(s *Settings) { s.Muted = !s.Muted })
+	if currentSettings().Muted || LoadSettings(prefs).Muted {
+		t.Error("unmuting in the menu did not stick")
+	}
+	if currentSettings().Language != "bg" || LoadSettings(prefs).Language != "en" {
+		t.Error("the language option was lost or saved")
+	}
+}
+
+func TestGameOptionsAreNotSaved(t *testing.T) {
+	test.NewTempApp(t)
+	defer func() { runOptions = DefaultOptions() }()
+	runOptions.Seed, runOptions.Trays = 42, 8
+	prefs := fyne.CurrentApp().Preferences()
+	w := test.NewWindow(nil)
+	defer w.Close()
+
+	showLobby(w)
+	var start *widget.Button
+	for _, o := range test.LaidOutObjects(w.Content()) {
+		if b, ok := o.(*widget.Button); ok && b.Text == T("lobby.start") {
+			start = b
+		}
+	}
+	if start == nil {
+		t.Fatal("no start button")
+	}
+	test.Tap(start)
+	if g := activeGame; g == nil || g.seed != 42 || g.numTrays != 8 {
+		t.Fatalf("the game did not use --seed and --trays: %+v", g)
+	}
+	if cfg := LoadGameConfig(prefs); cfg.Seed != 0 || cfg.NumTrays != DefaultGameConfig().NumTrays {
+		t.Errorf("the options were saved with the start screen: seed %d, %d trays", cfg.Seed, cfg.NumTrays)
+	}
+}
+
+func TestChefOverridesAgainstPreset(t *testing.T) {
+	low, high := 1.2, 0.5
+	tests := []struct {
+		name       string
+		difficulty string
+		generosity float64
+		chef       ChefOverrides
+		ok         bool
+	}{
+		{"min above the preset's max", "normal", 1, ChefOverrides{MinFactor: &low}, false},
+		{"max below the preset's min", "easy", 1, ChefOverrides{MaxFactor: &high}, false},
+		{"max above a stingy min", "normal", 0.8, ChefOverrides{MaxFactor: &high}, true},
+		{"min that generosity makes room for", "easy", 1.2, ChefOverrides{MinFactor: &low}, true},
+	}
+	for _, tt := range tests {
+		cfg := testConfig()
+		cfg.Difficulty, cfg.Generosity, cfg.Chef = tt.difficulty, tt.generosity, tt.chef
+		c, err := chefFor(cfg, 1)
+		if (err == nil) != tt.ok || c.minFactor > c.maxFactor {
+			t.Errorf("%s: Chef %v..%v, %v", tt.name, c.minFactor, c.maxFactor, err)
+		}
+	}
+}
+
+func TestOfferEvery(t *testing.T) {
+	cfg := testConfig()
+	cfg.OfferEvery = 2
+	g, w := newTestGame(t, cfg)
+	g.chef.swapChance = 0
+	pickTray(t, g, w, 0)
+
+	openTrays(t, g, w, 2)
+	if len(g.offers) != 1 {
+		t.Fatalf("want an offer after 2 trays, got %d", len(g.offers))
+	}
+	tapDialog(t, w, T("decline"))
+	openTrays(t, g, w, 2)
+	if len(g.offers) != 2 {
+		t.Errorf("want a second offer after 4 trays, got %d", len(g.offers))
+	}
+}
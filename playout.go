@@ -0,0 +1,67 @@
+package main
+
+import (
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+// startPlayOut keeps the board going after a deal so the player can see
+// what would have happened. The winnings were already recorded.
+func (g *Game) startPlayOut(parent fyne.Window, deal int) {
+	g.playingOut = true
+	g.dealAmount = deal
+	if g.getUnopenedCount() <= 1 {
+		g.showPlayOutResult(parent)
+		return
+	}
+	dialog.ShowInformation(T("playout.title"), T("playout.body"), parent)
+}
+
+// showWouldBeOffer is the Chef's offer during a play-out: no bonuses or
+// swaps, just the cash he would have put on the table
+func (g *Game) showWouldBeOffer(parent fyne.Window, remaining []int) {
+	o := Offer{Kind: CashOffer, Final: g.getUnopenedCount() == 1}
+	o.Base = g.chef.CalculateOffer(remaining)
+	o.Amount = o.Base
+	g.recordOffer(o)
+	g.respond(ResponseWouldHave)
+
+	verdict := T("playout.better")
+	if o.Amount <= g.dealAmount {
+		verdict = T("playout.worse")
+	}
+	d := dialog.NewInformation(T("playout.offer_title"),
+		T("playout.offer", Money(o.Amount), Money(g.dealAmount))+"\n"+verdict, parent)
+	d.SetOnClosed(func() { g.offerResolved(parent) })
+	d.Show()
+}
+
+// showPlayOutResult opens the player's tray (and the last one left) and
+// tells whether the deal was a good one
+func (g *Game) showPlayOutResult(parent fyne.Window) {
+	views := container.NewHBox(g.trayRevealView(g.playerTray, g.pack.TrayIcon+" "+T("reveal.your_tray")))
+	if other := g.lastOtherTray(); other != -1 {
+		views.Add(widget.NewSeparator())
+		views.Add(g.trayRevealView(other, T("reveal.other_tray")))
+	}
+
+	held := g.trayWinnings(g.playerTray)
+	verdict := T("playout.good_deal", Money(g.dealAmount), Money(held))
+	if held > g.dealAmount {
+		verdict = T("playout.bad_deal", Money(g.dealAmount), Money(held))
+	}
+
+	content := container.NewVBox(views, widget.NewSeparator(), container.NewCenter(widget.NewLabel(verdict)))
+	d := dialog.NewCustom(T("playout.result_title"), T("ok"), content, parent)
+	d.SetOnClosed(func() {
+		g.playingOut = false
+		g.lockBoard()
+		g.showPlayAgain(parent)
+	})
+	showCountdown(parent, 3, func() {
+		g.sound.Play(CueFinalReveal)
+		d.Show()
+	})
+}
@@ -0,0 +1,324 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/widget"
+)
+
+// A game report is built from a GameResult in the history and written as
+// Markdown or as a single HTML page that opens without a network.
+
+// reportSection is a heading with lines of text and an optional table
+type reportSection struct {
+	Title  string
+	Lines  []string
+	Header []string
+	Rows   [][]string
+}
+
+// Best is the most the player could have taken home knowing what was in
+// the trays: the highest cash offer, the tray they held or, at the final
+// decision, the last tray they could have swapped for
+func (r GameResult) Best() (best int, how string) {
+	best, how = r.TrayValue, T("report.best.keep")
+	for _, o := range r.Offers {
+		if o.Kind == CashOffer && o.Response != ResponseWouldHave && o.Amount > best {
+			best, how = o.Amount, T("report.best.offer", o.Round)
+		}
+	}
+	if !r.Deal && len(r.Board) > 0 {
+		opened := map[int]bool{}
+		for _, idx := range r.Opened {
+			opened[idx] = true
+		}
+		for i, t := range r.Board {
+			if i != r.PlayerTray && !opened[i] && t.Item == "" && t.Value > best {
+				best, how = t.Value, T("report.best.swap", i+1)
+			}
+		}
+	}
+	return best, how
+}
+
+// contents is a tray's value, or its food item
+func (t BoardTray) contents() string {
+	if t.Item != "" {
+		return t.Item
+	}
+	return Money(t.Value)
+}
+
+func (r GameResult) reportSections() []reportSection {
+	player := r.Player
+	if player == "" {
+		player = T("report.anonymous")
+	}
+	summary := reportSection{Title: T("report.result"), Lines: []string{
+		T("report.played", r.Time.Format("2006-01-02 15:04"), player, T("difficulty."+r.Difficulty), r.Trays, r.Seed),
+	}}
+	if r.Deal {
+		summary.Lines = append(summary.Lines, T("report.deal", Money(r.Winnings), Money(r.TrayValue)))
+	} else {
+		summary.Lines = append(summary.Lines, T("report.final", Money(r.Winnings)))
+	}
+	best, how := r.Best()
+	share := 100
+	if best > 0 {
+		share = r.Winnings * 100 / best
+	}
+	summary.Lines = append(summary.Lines, T("report.best", Money(best), how, share))
+	sections := []reportSection{summary}
+	if len(r.Board) == 0 {
+		summary.Lines = append(summary.Lines, T("report.no_detail"))
+		return []reportSection{summary}
+	}
+
+	// the board, with when each tray was opened
+	order := map[int]int{}
+	for n, idx := range r.Opened {
+		order[idx] = n + 1
+	}
+	board := reportSection{Title: T("report.board"), Header: []string{T("report.tray"), T("report.contents"), T("report.opened")}}
+	for i, t := range r.Board {
+		opened := ""
+		switch {
+		case i == r.PlayerTray:
+			opened = T("report.your_tray")
+		case order[i] > 0:
+			opened = T("report.nth", order[i])
+		case i == r.PickedTray:
+			opened = T("report.swapped_away")
+		}
+		board.Rows = append(board.Rows, []string{fmt.Sprint(i + 1), t.contents(), opened})
+	}
+
+	opened := reportSection{Title: T("report.order")}
+	for n, idx := range r.Opened {
+		opened.Lines = append(opened.Lines, T("report.order_row", n+1, idx+1, r.Board[idx].contents()))
+	}
+
+	offers := reportSection{Title: T("report.offers"), Header: []string{
+		T("report.round"), T("report.after"), T("report.offer"), T("report.ev"), T("report.percent"), T("report.bonus"), T("report.answer"),
+	}}
+	for _, o := range r.Offers {
+		amount, percent := T("history.swap"), ""
+		if o.Kind == CashOffer {
+			amount = Money(o.Amount)
+			if o.EV > 0 {
+				percent = fmt.Sprintf("%d%%", o.Amount*100/o.EV)
+			}
+		}
+		bonus := ""
+		if o.Bonus != "" {
+			bonus = T("report.bonus_effect", o.Bonus, Money(o.Base), Money(o.Amount))
+		}
+		answer := "…"
+		if o.Response != ResponsePending {
+			answer = T("history." + o.Response)
+		}
+		offers.Rows = append(offers.Rows, []string{fmt.Sprint(o.Round), fmt.Sprint(o.Opened), amount, Money(o.EV), percent, bonus, answer})
+	}
+	if len(offers.Rows) == 0 {
+		offers.Lines = []string{T("history.empty")}
+	}
+
+	bonuses := reportSection{Title: T("report.bonuses"), Lines: []string{T("report.no_bonus")}}
+	if len(r.Bonuses) > 0 {
+		bonuses.Lines = []string{T("report.bonus_cases", strings.Join(r.Bonuses, ", "))}
+	}
+	return append(sections, board, opened, offers, bonuses, r.optimalSection())
+}
+
+// optimalSection holds each answer to a cash offer against perfect play
+// for the highest expected value
+func (r GameResult) optimalSection() reportSection {
+	section := reportSection{Title: T("report.optimal")}
+	decisions, err := AnalyseGame(r, ExpectedValue)
+	var person OffersByPerson
+	if errors.As(err, &person) {
+		section.Lines = []string{T("report.optimal.by_" + person.By)}
+		return section
+	}
+	if err != nil {
+		section.Lines = []string{T("report.optimal.unavailable")}
+		return section
+	}
+	if len(decisions) == 0 {
+		section.Lines = []string{T("report.optimal.none")}
+		return section
+	}
+	answer := func(took bool) string {
+		if took {
+			return T("history.deal")
+		}
+		return T("history.no_deal")
+	}
+	section.Header = []string{T("report.round"), T("report.offer"), T("report.optimal.play_on"),
+		T("report.optimal.best"), T("report.answer"), T("report.optimal.cost")}
+	cost := 0
+	for _, d := range decisions {
+		lost := ""
+		if d.Cost > 0 {
+			lost = Money(d.Cost)
+		}
+		cost += d.Cost
+		section.Rows = append(section.Rows, []string{fmt.Sprint(d.Round), Money(d.Offer), Money(d.PlayOn),
+			answer(d.BestTake), answer(d.Took), lost})
+	}
+	if cost > 0 {
+		section.Lines = append(section.Lines, T("report.optimal.lost", Money(cost)))
+	} else {
+		section.Lines = append(section.Lines, T("report.optimal.perfect"))
+	}
+	bonused := []string{}
+	for _, o := range r.Offers {
+		if o.Bonus != "" && (o.Response == ResponseDeal || o.Response == ResponseNoDeal) {
+			bonused = append(bonused, fmt.Sprint(o.Round))
+		}
+	}
+	if len(bonused) > 0 {
+		section.Lines = append(section.Lines, T("report.optimal.bonus", strings.Join(bonused, ", ")))
+	}
+	section.Lines = append(section.Lines, T("report.optimal.note"))
+	return section
+}
+
+// Markdown is the report as a Markdown document
+func (r GameResult) Markdown() string {
+	var b strings.Builder
+	cell := func(s string) string { return strings.ReplaceAll(s, "|", `\|`) }
+	fmt.Fprintf(&b, "# %s\n", T("report.title"))
+	for _, s := range r.reportSections() {
+		fmt.Fprintf(&b, "\n## %s\n\n", s.Title)
+		for _, l := range s.Lines {
+			fmt.Fprintf(&b, "%s  \n", l)
+		}
+		if len(s.Header) == 0 {
+			continue
+		}
+		if len(s.Lines) > 0 {
+			b.WriteString("\n")
+		}
+		row := func(cells []string) {
+			for _, c := range cells {
+				fmt.Fprintf(&b, "| %s ", cell(c))
+			}
+			b.WriteString("|\n")
+		}
+		row(s.Header)
+		b.WriteString(strings.Repeat("|---", len(s.Header)) + "|\n")
+		for _, r := range s.Rows {
+			row(r)
+		}
+	}
+	return b.String()
+}
+
+// reportStyle keeps the HTML page readable with no files next to it
+const reportStyle = `body{font-family:sans-serif;max-width:52em;margin:2em auto;padding:0 1em}
+table{border-collapse:collapse}td,th{border:1px solid #ccc;padding:.3em .6em;text-align:left}
+th{background:#f4f4f4}`
+
+// HTML is the report as one self-contained web page
+func (r GameResult) HTML() string {
+	var b strings.Builder
+	e := html.EscapeString
+	fmt.Fprintf(&b, "<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n<title>%s</title>\n<style>%s</style>\n</head>\n<body>\n<h1>%s</h1>\n",
+		e(T("report.title")), reportStyle, e(T("report.title")))
+	for _, s := range r.reportSections() {
+		fmt.Fprintf(&b, "<h2>%s</h2>\n", e(s.Title))
+		for _, l := range s.Lines {
+			fmt.Fprintf(&b, "<p>%s</p>\n", e(l))
+		}
+		if len(s.Header) == 0 {
+			continue
+		}
+		b.WriteString("<table>\n<tr>")
+		for _, h := range s.Header {
+			fmt.Fprintf(&b, "<th>%s</th>", e(h))
+		}
+		b.WriteString("</tr>\n")
+		for _, row := range s.Rows {
+			b.WriteString("<tr>")
+			for _, c := range row {
+				fmt.Fprintf(&b, "<td>%s</td>", e(c))
+			}
+			b.WriteString("</tr>\n")
+		}
+		b.WriteString("</table>\n")
+	}
+	b.WriteString("</body>\n</html>\n")
+	return b.String()
+}
+
+// exportReport asks where to save the report of r. A file name ending in
+// .md gets Markdown, anything else HTML.
+func exportReport(w fyne.Window, r GameResult) {
+	d := dialog.NewFileSave(func(f fyne.URIWriteCloser, err error) {
+		if err != nil {
+			dialog.ShowError(err, w)
+			return
+		}
+		if f == nil {
+			return // cancelled
+		}
+		// working out the best play for a big board takes a moment
+		go func() {
+			defer f.Close()
+			var text string
+			if strings.EqualFold(filepath.Ext(f.URI().Name()), ".md") {
+				text = r.Markdown()
+			} else {
+				text = r.HTML()
+			}
+			if _, err := io.WriteString(f, text); err != nil {
+				fyne.Do(func() { dialog.ShowError(err, w) })
+			}
+		}()
+	}, w)
+	d.SetFileName("mealnomeal-" + r.Time.Format("2006-01-02-1504") + ".html")
+	d.SetFilter(storage.NewExtensionFileFilter([]string{".html", ".md"}))
+	d.Show()
+}
+
+// showResults lists the recorded games, latest first, each with a button
+// to export its report
+func showResults(w fyne.Window) {
+	leaveGame()
+	results := LoadResults(fyne.CurrentApp().Preferences())
+	list := container.NewVBox()
+	for i := len(results) - 1; i >= 0; i-- {
+		r := results[i]
+		player := r.Player
+		if player == "" {
+			player = T("report.anonymous")
+		}
+		outcome := T("results.final", Money(r.Winnings))
+		if r.Deal {
+			outcome = T("results.deal", Money(r.Winnings))
+		}
+		export := widget.NewButton(T("results.export"), func() { exportReport(w, r) })
+		list.Add(container.NewBorder(nil, nil, nil, export,
+			widget.NewLabel(r.Time.Format("2006-01-02 15:04")+" · "+player+" · "+outcome)))
+	}
+	if len(results) == 0 {
+		list.Add(widget.NewLabel(T("results.empty")))
+	}
+	back := widget.NewButton(T("results.back"), func() { showLobby(w) })
+	w.SetContent(container.NewBorder(
+		container.NewCenter(widget.NewLabel(T("results.title"))),
+		container.NewCenter(back),
+		nil, nil,
+		container.NewVScroll(list),
+	))
+}
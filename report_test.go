@@ -0,0 +1,129 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/test"
+)
+
+// sampleResult is a short recorded game: a 6-tray board where the player
+// kept tray 1, turned down two offers and won what was in their tray
+func sampleResult() GameResult {
+	return GameResult{
+		Time:       time.Date(2026, 10, 18, 15, 30, 0, 0, time.UTC),
+		Player:     "<Ana>",
+		Difficulty: "normal",
+		Trays:      6,
+		Seed:       42,
+		Winnings:   100,
+		TrayValue:  100,
+		Board: []BoardTray{
+			{Value: 100}, {Value: 1}, {Value: 1000000}, {Value: 5, Item: "Ramen"}, {Value: 500}, {Value: 25000},
+		},
+		PickedTray: 0,
+		PlayerTray: 0,
+		Opened:     []int{1, 3, 4, 5},
+		Offers: []OfferRecord{
+			{Round: 1, Kind: CashOffer, Base: 80000, Amount: 160000, Bonus: "×2", EV: 256275, Response: ResponseNoDeal, Opened: 3},
+			{Round: 2, Kind: SwapOffer, EV: 333366, Response: ResponseKept, Opened: 4},
+		},
+		Bonuses: []string{"*2"},
+	}
+}
+
+func TestBestOutcome(t *testing.T) {
+	test.NewTempApp(t)
+	tests := []struct {
+		name   string
+		change func(r *GameResult)
+		want   int
+	}{
+		{"the last tray was the million", func(r *GameResult) {}, 1000000},
+		{"a deal only knows the offers", func(r *GameResult) { r.Deal, r.Winnings = true, 160000 }, 160000},
+		{"keeping the tray", func(r *GameResult) { r.Board[2].Value, r.TrayValue = 1, 200000 }, 200000},
+		{"would-have offers do not count", func(r *GameResult) {
+			r.Deal = true
+			r.Offers = append(r.Offers, OfferRecord{Kind: CashOffer, Amount: 900000, Response: ResponseWouldHave})
+		}, 160000},
+		{"no detail", func(r *GameResult) { r.Board, r.Offers = nil, nil }, 100},
+	}
+	for _, tt := range tests {
+		r := sampleResult()
+		r.Board = append([]BoardTray{}, r.Board...)
+		tt.change(&r)
+		if got, _ := r.Best(); got != tt.want {
+			t.Errorf("%s: Best() = %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestMarkdownReport(t *testing.T) {
+	test.NewTempApp(t)
+	md := sampleResult().Markdown()
+	for _, want := range []string{
+		"# " + T("report.title"),
+		"| 3 | " + Money(1000000) + " |  |",
+		"| 4 | Ramen | #2 |",
+		"| 1 | " + Money(100) + " | " + T("report.your_tray") + " |",
+		"2. Tray 4 – Ramen",
+		"| 1 | 3 | " + Money(160000) + " | " + Money(256275) + " | 62% | ×2: " + Money(80000) + " → " + Money(160000) + " |",
+		T("report.bonus_cases", "*2"),
+		T("report.best", Money(1000000), T("report.best.swap", 3), 0),
+		T("report.optimal.bonus", "1"),
+	} {
+		if !strings.Contains(md, want) {
+			t.Errorf("report has no %q:\n%s", want, md)
+		}
+	}
+}
+
+func TestReportSkipsOffersByPeople(t *testing.T) {
+	test.NewTempApp(t)
+	for _, by := range []string{offerByBanker, offerByHost} {
+		r := sampleResult()
+		r.Offers[0].By = by
+		md := r.Markdown()
+		if !strings.Contains(md, T("report.optimal.by_"+by)) || strings.Contains(md, T("report.optimal.note")) {
+			t.Errorf("%s: the report grades offers a person made:\n%s", by, md)
+		}
+	}
+}
+
+func TestHTMLReportEscapes(t *testing.T) {
+	test.NewTempApp(t)
+	page := sampleResult().HTML()
+	if strings.Contains(page, "<Ana>") || !strings.Contains(page, "&lt;Ana&gt;") {
+		t.Error("the player's name is not escaped")
+	}
+	if strings.Contains(page, "http") {
+		t.Error("the report should not need the network")
+	}
+	if !strings.Contains(page, "<td>Ramen</td>") {
+		t.Error("no board table")
+	}
+}
+
+func TestGameRecordsReportDetail(t *testing.T) {
+	g, w := newTestGame(t, testConfig())
+	g.chef.swapChance = 0
+	pickTray(t, g, w, 0)
+	openTrays(t, g, w, 3)
+	tapDialog(t, w, T("accept"))
+
+	results := LoadResults(fyne.CurrentApp().Preferences())
+	if len(results) != 1 {
+		t.Fatalf("%d results, want 1", len(results))
+	}
+	r := results[0]
+	if len(r.Board) != g.numTrays || len(r.Opened) != 3 || len(r.Offers) != 1 || r.Offers[0].Base == 0 {
+		t.Errorf("recorded board %d, opened %v, offers %+v", len(r.Board), r.Opened, r.Offers)
+	}
+	for i, tray := range r.Board {
+		if tray.Value != g.sidebarValue(i) || tray.Item != g.itemNames[i] {
+			t.Errorf("tray %d recorded as %+v", i+1, tray)
+		}
+	}
+}
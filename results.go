@@ -0,0 +1,109 @@
+package main
+
+import (
+	"encoding/json"
+	"time"
+
+	"fyne.io/fyne/v2"
+)
+
+// How many finished games are kept in the preferences
+const maxResults = 200
+
+// GameResult is one finished game as kept in the history
+type GameResult struct {
+	Time       time.Time  `json:"time"`
+	Player     string     `json:"player,omitempty"`
+	Difficulty string     `json:"difficulty"`
+	Generosity float64    `json:"generosity,omitempty"`
+	Chef       *ChefModel `json:"chef,omitempty"` // the Chef as he played
+	Trays      int        `json:"trays"`
+	Seed       int64      `json:"seed"`
+	Deal       bool       `json:"deal"`     // took a Chef offer instead of playing to the end
+	Winnings   int        `json:"winnings"` // what the player took home (food = 0)
+	TrayValue  int        `json:"trayValue"`
+
+	// The game in detail, for the report (see report.go). Older entries
+	// have none of it.
+	Board      []BoardTray   `json:"board,omitempty"`
+	PickedTray int           `json:"pickedTray"` // the tray chosen at the start
+	PlayerTray int           `json:"playerTray"` // the tray held at the end, after any swaps
+	Opened     []int         `json:"opened,omitempty"`
+	Offers     []OfferRecord `json:"offers,omitempty"`
+	Bonuses    []string      `json:"bonuses,omitempty"` // bonus cases chosen, like "*3" or "-500"
+}
+
+// BoardTray is what a tray held
+type BoardTray struct {
+	Value int    `json:"value"`          // the value, or the one a food item replaced
+	Item  string `json:"item,omitempty"` // food item, worth nothing
+}
+
+func LoadResults(p fyne.Preferences) []GameResult {
+	var results []GameResult
+	if data := p.String("results.history"); data != "" {
+		if err := json.Unmarshal([]byte(data), &results); err != nil {
+			fyne.LogError("Could not read game history", err)
+		}
+	}
+	return results
+}
+
+func SaveResults(p fyne.Preferences, results []GameResult) {
+	if len(results) > maxResults {
+		results = results[len(results)-maxResults:]
+	}
+	data, err := json.Marshal(results)
+	if err != nil {
+		fyne.LogError("Could not save game history", err)
+		return
+	}
+	p.SetString("results.history", string(data))
+}
+
+// boardRecord is what every tray held
+func (g *Game) boardRecord() []BoardTray {
+	board := make([]BoardTray, g.numTrays)
+	for i := range board {
+		board[i] = BoardTray{Value: g.sidebarValue(i), Item: g.itemNames[i]}
+	}
+	return board
+}
+
+// recordResult adds the game's outcome to the history
+func (g *Game) recordResult(deal bool, winnings int) {
+	g.recorded = true
+	p := fyne.CurrentApp().Preferences()
+	// a finished game is not resumed, or it would be recorded twice
+	clearSavedGame(p)
+	SaveResults(p, append(LoadResults(p), GameResult{
+		Time:       time.Now(),
+		Player:     g.config.PlayerName,
+		Difficulty: difficultyByName(g.config.Difficulty).Name,
+		Generosity: g.config.Generosity,
+		Chef:       &ChefModel{g.chef.minFactor, g.chef.maxFactor, g.chef.swapChance, g.config.OfferEvery},
+		Trays:      g.numTrays,
+		Seed:       g.seed,
+		Deal:       deal,
+		Winnings:   winnings,
+		TrayValue:  g.trayWinnings(g.playerTray),
+		Board:      g.boardRecord(),
+		PickedTray: g.pickedTray,
+		PlayerTray: g.playerTray,
+		Opened:     append([]int{}, g.openedOrder...),
+		Offers:     append([]OfferRecord{}, g.offers...),
+		Bonuses:    append([]string{}, g.bonus.picks...),
+	}))
+	if g.daily != "" {
+		g.recordDaily(deal, winnings)
+	}
+	if g.tournamentGame != 0 {
+		g.recordTournament(deal, winnings)
+	}
+
+	if deal && len(g.offers) > 0 {
+		last := g.offers[len(g.offers)-1]
+		g.achieve(GameEvent{Kind: EventDeal, Amount: winnings, EV: last.EV, Multiplier: last.Multiplier})
+	}
+	g.achieve(GameEvent{Kind: EventGameEnd, Amount: winnings})
+}
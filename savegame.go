@@ -0,0 +1,185 @@
+package main
+
+import (
+	"encoding/json"
+	"math/rand"
+
+	"fyne.io/fyne/v2"
+)
+
+// SavedGame is a game in progress. It is kept in the preferences when the
+// app goes to the background, as a phone may close the app after that, and
+// offered on the start screen to pick up again.
+type SavedGame struct {
+	Config         GameConfig    `json:"config"`
+	Seed           int64         `json:"seed"`
+	TrayValues     []int         `json:"trayValues"`
+	TrayReplaced   []int         `json:"trayReplaced"`
+	ItemNames      []string      `json:"itemNames"`
+	ItemImages     []string      `json:"itemImages"`
+	PlayerTray     int           `json:"playerTray"`
+	PickedTray     int           `json:"pickedTray"`
+	OpenedOrder    []int         `json:"openedOrder"`
+	Offers         []OfferRecord `json:"offers"`
+	BonusOffered   bool          `json:"bonusOffered"`
+	MultiplierUsed bool          `json:"multiplierUsed"`
+	AdditiveUsed   bool          `json:"additiveUsed"`
+	BonusPicks     []string      `json:"bonusPicks"`
+	Multiplier     float64       `json:"multiplier"`     // bonus picked but not applied to an offer yet
+	MultiplierCase string        `json:"multiplierCase"` // likewise
+	Additive       int           `json:"additive"`       // likewise
+	ChefDraws      int           `json:"chefDraws"`      // random numbers the Chef used so far
+	BonusDraws     int           `json:"bonusDraws"`     // and the bonus cases
+	Daily          string        `json:"daily"`
+	TournamentGame int           `json:"tournamentGame"`
+}
+
+func LoadSavedGame(p fyne.Preferences) *SavedGame {
+	data := p.String("game.saved")
+	if data == "" {
+		return nil
+	}
+	s := &SavedGame{}
+	if err := json.Unmarshal([]byte(data), s); err != nil {
+		fyne.LogError("Could not read the saved game", err)
+		return nil
+	}
+	return s
+}
+
+func (s *SavedGame) Save(p fyne.Preferences) {
+	data, err := json.Marshal(s)
+	if err != nil {
+		fyne.LogError("Could not save the game", err)
+		return
+	}
+	p.SetString("game.saved", string(data))
+}
+
+// countedSource is a random source that counts the numbers drawn from it,
+// so a saved game can carry on from the same point of its sequence
+type countedSource struct {
+	rand.Source64
+	draws int
+}
+
+func newCountedSource(seed int64) *countedSource {
+	return &countedSource{Source64: rand.NewSource(seed).(rand.Source64)}
+}
+
+func (s *countedSource) Int63() int64 {
+	s.draws++
+	return s.Source64.Int63()
+}
+
+func (s *countedSource) Uint64() uint64 {
+	s.draws++
+	return s.Source64.Uint64()
+}
+
+// skip draws until draws numbers have been used, to continue a sequence
+func (s *countedSource) skip(draws int) {
+	for s.draws < draws {
+		s.Int63()
+	}
+}
+
+func clearSavedGame(p fyne.Preferences) {
+	p.RemoveValue("game.saved")
+}
+
+// saveActiveGame keeps the game on screen, if it is worth resuming: the
+// player's tray is picked, the board not locked and the result not recorded
+// yet
+func saveActiveGame() {
+	g := activeGame
+	if g == nil || g.playerTray == -1 || g.finished || g.recorded {
+		return
+	}
+	s := &SavedGame{
+		Config:         g.config,
+		Seed:           g.seed,
+		TrayValues:     g.trayValues,
+		TrayReplaced:   g.trayReplaced,
+		ItemNames:      g.itemNames,
+		ItemImages:     g.itemImages,
+		PlayerTray:     g.playerTray,
+		PickedTray:     g.pickedTray,
+		OpenedOrder:    g.openedOrder,
+		Offers:         g.offers,
+		BonusOffered:   g.bonusOffered,
+		MultiplierUsed: g.bonus.multiplierUsed,
+		AdditiveUsed:   g.bonus.additiveUsed,
+		BonusPicks:     g.bonus.picks,
+		Multiplier:     g.bonus.multiplier,
+		MultiplierCase: g.bonus.multiplierCase,
+		Additive:       g.bonus.additive,
+		ChefDraws:      g.chef.src.draws,
+		BonusDraws:     g.bonus.src.draws,
+		Daily:          g.daily,
+		TournamentGame: g.tournamentGame,
+	}
+	s.Save(fyne.CurrentApp().Preferences())
+}
+
+// resumeGame puts a saved game back on the board in w. The Chef and the
+// bonus cases pick up their random numbers where the game left off, so a
+// seeded game makes the same offers it would have made.
+func resumeGame(w fyne.Window, s *SavedGame) *Game {
+	clearSavedGame(fyne.CurrentApp().Preferences())
+	cfg := s.Config
+	cfg.Seed = s.Seed
+	g := NewGame(cfg)
+	g.config = s.Config // a random board stays random for Play Again
+	g.chef.src.skip(s.ChefDraws)
+	g.bonus.src.skip(s.BonusDraws)
+	g.win = w
+	g.initialize()
+
+	// the trays as saved, in case the theme pack's items changed since
+	g.trayValues, g.trayReplaced = s.TrayValues, s.TrayReplaced
+	g.itemNames, g.itemImages = s.ItemNames, s.ItemImages
+	g.bonusOffered = s.BonusOffered
+	g.bonus.multiplierUsed, g.bonus.additiveUsed = s.MultiplierUsed, s.AdditiveUsed
+	g.bonus.picks = s.BonusPicks
+	if s.Multiplier != 0 {
+		g.bonus.multiplier, g.bonus.multiplierCase = s.Multiplier, s.MultiplierCase
+	}
+	g.bonus.additive = s.Additive
+	g.daily, g.tournamentGame = s.Daily, s.TournamentGame
+	g.offers = s.Offers
+
+	g.playerTray, g.pickedTray = s.PlayerTray, s.PickedTray
+	g.showPlayerBoard(fyne.CurrentApp())
+	for _, idx := range s.OpenedOrder {
+		g.gridButtons[idx].Disable()
+		g.openedTraysCount++
+		g.openedOrder = append(g.openedOrder, idx)
+		g.openedValues[g.sidebarValue(idx)] = true
+	}
+	g.refreshHistory()
+	g.changed()
+	g.verify("resuming the game")
+	g.resumeTurn()
+	return g
+}
+
+// resumeTurn carries on from where the game was saved: the offer that was
+// on the table, an offer that was due, or the next pick
+func (g *Game) resumeTurn() {
+	n := len(g.offers)
+	if n > 0 && g.offers[n-1].Response == ResponsePending {
+		if g.offers[n-1].Kind == SwapOffer {
+			g.showSwapOfferDialog(g.win)
+		} else {
+			g.showOfferDialog(g.win, g.offers[n-1].Amount)
+		}
+		return
+	}
+	due := g.openedTraysCount > 0 && g.offerDue()
+	if due && (n == 0 || g.offers[n-1].Opened < g.openedTraysCount) {
+		g.showChefOffer(g.win)
+		return
+	}
+	g.offerResolved(g.win)
+}
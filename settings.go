@@ -0,0 +1,249 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/widget"
+)
+
+// Settings are the player's options kept in the app preferences
+type Settings struct {
+	ReduceMotion bool    // skip animations and the final countdown
+	PlayItOut    bool    // after a deal, keep opening trays to see what would have happened
+	TVMode       bool    // full screen with big text, for projecting
+	Volume       float64 // 0..1
+	Muted        bool
+	Music        bool   // background music on/off
+	ThemePack    string // path of the theme pack, "" for the built-in one
+	Language     string // "en", "bg"
+	Currency     string // "USD", "EUR", "BGN"
+}
+
+func LoadSettings(p fyne.Preferences) *Settings {
+	return &Settings{
+		ReduceMotion: p.Bool("settings.reduceMotion"),
+		PlayItOut:    p.Bool("settings.playItOut"),
+		TVMode:       p.Bool("settings.tvMode"),
+		Volume:       p.FloatWithFallback("settings.volume", 0.8),
+		Muted:        p.Bool("settings.muted"),
+		Music:        p.BoolWithFallback("settings.music", true),
+		ThemePack:    p.String("settings.themePack"),
+		Language:     p.StringWithFallback("settings.language", "en"),
+		Currency:     p.StringWithFallback("settings.currency", "USD"),
+	}
+}
+
+func (s *Settings) Save(p fyne.Preferences) {
+	p.SetBool("settings.reduceMotion", s.ReduceMotion)
+	p.SetBool("settings.playItOut", s.PlayItOut)
+	p.SetBool("settings.tvMode", s.TVMode)
+	p.SetFloat("settings.volume", s.Volume)
+	p.SetBool("settings.muted", s.Muted)
+	p.SetBool("settings.music", s.Music)
+	p.SetString("settings.themePack", s.ThemePack)
+	p.SetString("settings.language", s.Language)
+	p.SetString("settings.currency", s.Currency)
+}
+
+// Current settings of the running app, with the options it was started with
+func currentSettings() *Settings {
+	s := LoadSettings(fyne.CurrentApp().Preferences())
+	runOptions.overrideSettings(s)
+	return s
+}
+
+// Load, change and save the settings in one go. The options of this run
+// are not saved along.
+func updateSettings(update func(s *Settings)) {
+	p := fyne.CurrentApp().Preferences()
+	saved := LoadSettings(p)
+	s := *saved
+	runOptions.overrideSettings(&s)
+	before := s
+	update(&s)
+	runOptions.keepSaved(&s, before, *saved)
+	s.Save(p)
+}
+
+// checkItem is a menu entry toggling one boolean setting
+func checkItem(label string, get func(s *Settings) *bool, menu **fyne.Menu) *fyne.MenuItem {
+	item := fyne.NewMenuItem(label, nil)
+	item.Checked = *get(currentSettings())
+	item.Action = func() {
+		item.Checked = !item.Checked
+		updateSettings(func(s *Settings) { *get(s) = item.Checked })
+		gameSound.Apply(currentSettings())
+		(*menu).Refresh()
+	}
+	return item
+}
+
+// Dialog with a slider for the sound volume
+func showVolumeDialog(parent fyne.Window) {
+	value := widget.NewLabel("")
+	slider := widget.NewSlider(0, 100)
+	slider.Step = 5
+	slider.Value = currentSettings().Volume * 100
+	value.SetText(fmt.Sprintf("%.0f%%", slider.Value))
+	slider.OnChangeEnded = func(v float64) {
+		updateSettings(func(s *Settings) { s.Volume = v / 100 })
+		gameSound.Apply(currentSettings())
+		gameSound.Play(CueTrayOpen)
+	}
+	slider.OnChanged = func(v float64) {
+		value.SetText(fmt.Sprintf("%.0f%%", v))
+	}
+
+	dialog.ShowCustom(T("volume.title"), T("ok"), widget.NewForm(widget.NewFormItem(T("volume.title"), slider), widget.NewFormItem("", value)), parent)
+}
+
+// Main menu with the settings toggles
+func newMainMenu(w fyne.Window) *fyne.MainMenu {
+	var settingsMenu, soundMenu *fyne.Menu
+
+	tv := checkItem(T("menu.tv_mode"), func(s *Settings) *bool { return &s.TVMode }, &settingsMenu)
+	toggle := tv.Action
+	tv.Action = func() {
+		toggle()
+		applyTVMode(w, tv.Checked)
+	}
+	settingsMenu = fyne.NewMenu(T("menu.settings"),
+		checkItem(T("menu.reduce_motion"), func(s *Settings) *bool { return &s.ReduceMotion }, &settingsMenu),
+		checkItem(T("menu.play_it_out"), func(s *Settings) *bool { return &s.PlayItOut }, &settingsMenu),
+		tv,
+	)
+	soundMenu = fyne.NewMenu(T("menu.sound"),
+		checkItem(T("menu.mute"), func(s *Settings) *bool { return &s.Muted }, &soundMenu),
+		checkItem(T("menu.music"), func(s *Settings) *bool { return &s.Music }, &soundMenu),
+		fyne.NewMenuItem(T("menu.volume"), func() { showVolumeDialog(w) }),
+	)
+
+	return fyne.NewMainMenu(settingsMenu, soundMenu, newThemeMenu(w), newLanguageMenu(w))
+}
+
+// Language menu: the languages, then the currencies
+func newLanguageMenu(w fyne.Window) *fyne.Menu {
+	// the whole main menu is rebuilt so its own labels change language too
+	relabel := func(update func(s *Settings)) {
+		updateSettings(update)
+		applyLocale(currentSettings())
+		w.SetMainMenu(newMainMenu(w))
+		if activeGame != nil {
+			activeGame.relabel()
+		} else {
+			showLobby(w) // still on the start screen
+		}
+	}
+
+	menu := fyne.NewMenu(T("menu.language"))
+	for _, l := range languages {
+		code := l.Code
+		item := fyne.NewMenuItem(l.Name, func() {
+			relabel(func(s *Settings) { s.Language = code })
+		})
+		item.Checked = code == currentLang
+		menu.Items = append(menu.Items, item)
+	}
+	menu.Items = append(menu.Items, fyne.NewMenuItemSeparator())
+	for _, c := range currencies {
+		code := c.Code
+		item := fyne.NewMenuItem(T("currency."+code), func() {
+			relabel(func(s *Settings) { s.Currency = code })
+		})
+		item.Checked = code == currentCurrency
+		menu.Items = append(menu.Items, item)
+	}
+	return menu
+}
+
+// themesDir is where theme packs are looked for: $MEALNOMEAL_THEMES or
+// "themes" in the app's storage
+func themesDir() string {
+	if dir := os.Getenv("MEALNOMEAL_THEMES"); dir != "" {
+		return dir
+	}
+	return filepath.Join(fyne.CurrentApp().Storage().RootURI().Path(), "themes")
+}
+
+// switchThemePack loads the pack at path ("" for the built-in one), applies
+// it to the running game and remembers it
+func switchThemePack(path string) error {
+	p := defaultThemePack()
+	if path != "" {
+		var err error
+		if p, err = LoadThemePack(path); err != nil {
+			return err
+		}
+	}
+	old := currentPack
+	useThemePack(p)
+	updateSettings(func(s *Settings) { s.ThemePack = path })
+	if activeGame != nil {
+		activeGame.applyThemePack(p)
+	}
+	if old != p {
+		old.Close()
+	}
+	return nil
+}
+
+// Theme menu: the built-in pack, packs found in themesDir and a file picker
+func newThemeMenu(w fyne.Window) *fyne.Menu {
+	menu := fyne.NewMenu(T("menu.theme"))
+	paths := append([]string{""}, findThemePacks(themesDir())...)
+
+	var items []*fyne.MenuItem
+	markCurrent := func() {
+		current := currentSettings().ThemePack
+		for i, item := range items {
+			item.Checked = paths[i] == current
+		}
+		menu.Refresh()
+	}
+	choose := func(path string) {
+		if err := switchThemePack(path); err != nil {
+			dialog.ShowError(err, w)
+			return
+		}
+		markCurrent()
+	}
+
+	for _, path := range paths {
+		label := T("menu.theme.builtin", defaultThemePack().Name)
+		if path != "" {
+			label = filepath.Base(path)
+		}
+		item := fyne.NewMenuItem(label, func() { choose(path) })
+		items = append(items, item)
+		menu.Items = append(menu.Items, item)
+	}
+
+	openZip := fyne.NewMenuItem(T("menu.theme.open_zip"), func() {
+		d := dialog.NewFileOpen(func(r fyne.URIReadCloser, err error) {
+			if err != nil || r == nil {
+				return
+			}
+			r.Close()
+			choose(r.URI().Path())
+		}, w)
+		d.SetFilter(storage.NewExtensionFileFilter([]string{".zip"}))
+		d.Show()
+	})
+	openDir := fyne.NewMenuItem(T("menu.theme.open_dir"), func() {
+		dialog.ShowFolderOpen(func(dir fyne.ListableURI, err error) {
+			if err != nil || dir == nil {
+				return
+			}
+			choose(dir.Path())
+		}, w)
+	})
+	menu.Items = append(menu.Items, fyne.NewMenuItemSeparator(), openZip, openDir)
+
+	markCurrent()
+	return menu
+}
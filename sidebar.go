@@ -0,0 +1,132 @@
+package main
+
+import (
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/data/binding"
+	"fyne.io/fyne/v2/widget"
+)
+
+// ValueStatus is what the sidebar shows for one board value. It is a set
+// of flags: a value hidden under a food item can also be opened.
+type ValueStatus int
+
+const (
+	ValueHidden ValueStatus = 0 // still in a closed tray
+	ValueMasked ValueStatus = 1 // its tray holds a food item instead
+	ValueOpened ValueStatus = 2 // its tray has been opened
+)
+
+// valueStatus works the status of g.values[i] out from the game model. The
+// value of a tray still being revealed is not shown as opened yet.
+func (g *Game) valueStatus(v int) ValueStatus {
+	s := ValueHidden
+	for _, r := range g.trayReplaced {
+		if r == v {
+			s |= ValueMasked
+		}
+	}
+	if g.openedValues[v] && (g.revealing == -1 || g.sidebarValue(g.revealing) != v) {
+		s |= ValueOpened
+	}
+	return s
+}
+
+// syncSidebar pushes the game model into the sidebar bindings. It watches
+// the game, so every change reaches the sidebar the same way it reaches
+// the host console; only the values whose status changed update their
+// labels.
+func (g *Game) syncSidebar() {
+	for i, v := range g.values {
+		g.sidebar[i].Set(int(g.valueStatus(v)))
+	}
+}
+
+// valueText is the label for a board value with a given status
+func (g *Game) valueText(v int, s ValueStatus) string {
+	if s&ValueMasked != 0 {
+		return g.pack.ItemLabel
+	}
+	return Money(v)
+}
+
+// valueCell is a sidebar entry bound to the status of one value. Opened
+// values are greyed out and struck through.
+type valueCell struct {
+	widget.BaseWidget
+	status binding.Int
+	text   func(s ValueStatus) string
+	label  *widget.Label
+	strike *canvas.Line
+	drawn  float32 // how much of the strike is drawn, 0..1
+	shown  bool    // status has been shown once; later changes animate
+	last   ValueStatus
+}
+
+func newValueCell(status binding.Int, text func(s ValueStatus) string) *valueCell {
+	c := &valueCell{status: status, text: text, label: widget.NewLabel("")}
+	c.strike = canvas.NewLine(strikeColor)
+	c.strike.StrokeWidth = 2
+	c.ExtendBaseWidget(c)
+	status.AddListener(binding.NewDataListener(c.update))
+	return c
+}
+
+// update redraws the cell after its status (or the language) changed
+func (c *valueCell) update() {
+	v, _ := c.status.Get()
+	s := ValueStatus(v)
+	c.label.SetText(c.text(s))
+
+	opened := s&ValueOpened != 0
+	wasOpened := c.last&ValueOpened != 0
+	c.last = s
+	switch {
+	case !opened:
+		c.drawn = 0
+		c.label.Importance = widget.MediumImportance
+	case !wasOpened && c.shown && !currentSettings().ReduceMotion:
+		c.label.Importance = widget.LowImportance
+		fyne.NewAnimation(400*time.Millisecond, func(p float32) {
+			c.drawn = p
+			c.Refresh()
+		}).Start()
+	default:
+		c.drawn = 1
+		c.label.Importance = widget.LowImportance
+	}
+	c.shown = true
+	c.label.Refresh()
+	c.Refresh()
+}
+
+func (c *valueCell) CreateRenderer() fyne.WidgetRenderer {
+	return &valueCellRenderer{c: c}
+}
+
+type valueCellRenderer struct {
+	c *valueCell
+}
+
+func (r *valueCellRenderer) Layout(size fyne.Size) {
+	r.c.label.Resize(size)
+	y := size.Height / 2
+	r.c.strike.Position1 = fyne.NewPos(0, y)
+	r.c.strike.Position2 = fyne.NewPos(size.Width*r.c.drawn, y)
+}
+
+func (r *valueCellRenderer) MinSize() fyne.Size { return r.c.label.MinSize() }
+
+func (r *valueCellRenderer) Refresh() {
+	r.c.strike.Hidden = r.c.drawn == 0
+	r.Layout(r.c.Size())
+	r.c.strike.Refresh()
+}
+
+func (r *valueCellRenderer) Objects() []fyne.CanvasObject {
+	return []fyne.CanvasObject{r.c.label, r.c.strike}
+}
+
+func (r *valueCellRenderer) Destroy() {}
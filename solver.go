@@ -0,0 +1,259 @@
+package main
+
+import (
+	"errors"
+	"math"
+	"math/bits"
+)
+
+// The solver plays a board perfectly from the player's side of the table:
+// the player does not know what is in their own tray, so every tray still
+// in play is equally likely to be opened next and equally likely to be
+// theirs. That makes the state just the set of trays still in play, and the
+// best play is found by backwards induction over every subset of it.
+//
+// At an offer the Chef offers a swap (worth nothing either way, as the
+// trays are alike to the player) or cash: the average of the money left
+// times a factor drawn evenly from his range. Bonus rounds are not modelled.
+// The keep-or-swap choice at the end is a coin flip for the same reason.
+
+// ChefModel is what the solver knows about how the Chef makes offers
+type ChefModel struct {
+	MinFactor  float64 `json:"minFactor"`
+	MaxFactor  float64 `json:"maxFactor"`
+	SwapChance float64 `json:"swapChance"`
+	Every      int     `json:"every"` // he calls after every this many trays
+}
+
+// chefModel is the Chef of a game played with that preset and generosity
+func chefModel(difficulty string, generosity float64) ChefModel {
+	d := difficultyByName(difficulty)
+	if generosity == 0 {
+		generosity = 1
+	}
+	return ChefModel{d.MinFactor * generosity, d.MaxFactor * generosity, d.SwapChance, 3}
+}
+
+// Utility is what the player maximises: plain expected value, or an
+// expected utility for players who would rather have a sure thing
+type Utility struct {
+	Name    string
+	U       func(money float64) float64
+	Inverse func(u float64) float64 // money worth the same as utility u
+}
+
+var (
+	ExpectedValue = Utility{"ev", func(x float64) float64 { return x }, func(u float64) float64 { return u }}
+	LogUtility    = Utility{"log", math.Log1p, math.Expm1}
+)
+
+// Most trays the solver takes; its table has 2^n entries. A full board of
+// 26 has 23 trays left at the first offer.
+const maxSolverTrays = 23
+
+// Points the Chef's factor is sampled at
+const factorSamples = 32
+
+// Solver holds the best value of every set of trays still in play
+type Solver struct {
+	values  []int // money in each tray in play at the start, food as 0
+	total   int   // trays on the whole board, for when offers come
+	chef    ChefModel
+	utility Utility
+	best    []float32 // by bitmask over values: expected utility with best play
+	playOn  []float32 // ...and of turning down the offer or opening on
+}
+
+// NewSolver works out the best play from the trays in play (the player's
+// included) on a board of total trays
+func NewSolver(values []int, total int, chef ChefModel, u Utility) (*Solver, error) {
+	if len(values) > maxSolverTrays {
+		return nil, errors.New("too many trays to solve")
+	}
+	if len(values) < 2 {
+		return nil, errors.New("nothing left to decide")
+	}
+	s := &Solver{values: values, total: total, chef: chef, utility: u}
+	s.solve()
+	return s, nil
+}
+
+// isOffer tells whether the Chef calls with left trays in play: every few
+// trays and when only the player's and one more are left
+func (s *Solver) isOffer(left int) bool {
+	every := s.chef.Every
+	if every < 1 {
+		every = 3
+	}
+	opened := s.total - left
+	return opened > 0 && (opened%every == 0 || left == 2)
+}
+
+func (s *Solver) solve() {
+	n := uint32(1) << len(s.values)
+	s.best = make([]float32, n)
+	s.playOn = make([]float32, n)
+	// a set only leads to smaller ones, which come first in this order
+	for mask := uint32(1); mask < n; mask++ {
+		left := bits.OnesCount32(mask)
+		if left < 2 {
+			continue // the game never gets here
+		}
+		var on float64
+		if left == 2 {
+			on = s.meanUtility(mask) // keep or swap, it is the same to the player
+		} else {
+			for m := mask; m != 0; m &= m - 1 {
+				on += float64(s.best[mask&^(m&-m)])
+			}
+			on /= float64(left)
+		}
+		s.playOn[mask] = float32(on)
+		s.best[mask] = float32(on)
+		if s.isOffer(left) {
+			s.best[mask] = float32(s.offerValue(mask, on))
+		}
+	}
+}
+
+// meanUtility is the expected utility of getting one of the trays at random
+func (s *Solver) meanUtility(mask uint32) float64 {
+	total := 0.0
+	for m := mask; m != 0; m &= m - 1 {
+		total += s.utility.U(float64(s.values[bits.TrailingZeros32(m)]))
+	}
+	return total / float64(bits.OnesCount32(mask))
+}
+
+// offerValue is what an offer is worth before the player sees it, when
+// turning it down is worth on
+func (s *Solver) offerValue(mask uint32, on float64) float64 {
+	sum, count := 0, 0
+	for m := mask; m != 0; m &= m - 1 {
+		if v := s.values[bits.TrailingZeros32(m)]; v > 0 {
+			sum += v
+			count++
+		}
+	}
+	cash := math.Max(s.utility.U(0), on)
+	if count > 0 {
+		avg := float64(sum) / float64(count)
+		cash = 0
+		width := s.chef.MaxFactor - s.chef.MinFactor
+		for i := 0; i < factorSamples; i++ {
+			f := s.chef.MinFactor + width*(float64(i)+0.5)/factorSamples
+			offer := math.Max(1, math.Floor(avg*f))
+			cash += math.Max(s.utility.U(offer), on)
+		}
+		cash /= factorSamples
+	}
+	return s.chef.SwapChance*on + (1-s.chef.SwapChance)*cash
+}
+
+// PlayOn is the expected utility of turning the offer down (or of opening
+// on) with the trays in mask still in play, playing perfectly afterwards
+func (s *Solver) PlayOn(mask uint32) float64 {
+	return float64(s.playOn[mask])
+}
+
+// Threshold is the policy at an offer: take any offer of at least this
+// much, turn down anything less
+func (s *Solver) Threshold(mask uint32) int {
+	return int(math.Ceil(s.utility.Inverse(s.PlayOn(mask))))
+}
+
+// Decision is a cash offer the player answered, next to the best answer
+type Decision struct {
+	Round    int
+	Offer    int
+	PlayOn   int  // what turning it down was worth, in money
+	Took     bool // the player took the deal
+	BestTake bool // taking it was the best answer
+	Cost     int  // how much the player's answer gave away, 0 if it was the best
+}
+
+// OffersByPerson is returned by AnalyseGame for a game where the host or a
+// manual banker made offers: perfect play against the computer Chef says
+// nothing about those
+type OffersByPerson struct{ By string }
+
+func (e OffersByPerson) Error() string {
+	return "offers made by the " + e.By
+}
+
+// AnalyseGame replays the offers of a recorded game against perfect play.
+// Offers changed by a bonus are graded as they were made, but the best play
+// does not count on later bonuses.
+func AnalyseGame(r GameResult, u Utility) ([]Decision, error) {
+	for _, o := range r.Offers {
+		if o.By != "" {
+			return nil, OffersByPerson{o.By}
+		}
+	}
+	var first *OfferRecord
+	for i, o := range r.Offers {
+		if o.Kind == CashOffer && (o.Response == ResponseDeal || o.Response == ResponseNoDeal) {
+			first = &r.Offers[i]
+			break
+		}
+	}
+	if len(r.Board) == 0 || first == nil {
+		return nil, nil
+	}
+
+	// the solver starts from the trays in play at the first cash offer
+	openedAt := func(n int) map[int]bool {
+		opened := map[int]bool{}
+		for _, idx := range r.Opened[:min(n, len(r.Opened))] {
+			opened[idx] = true
+		}
+		return opened
+	}
+	start := openedAt(first.Opened)
+	trays, values := []int{}, []int{}
+	for i, t := range r.Board {
+		if !start[i] {
+			trays = append(trays, i)
+			v := t.Value
+			if t.Item != "" {
+				v = 0
+			}
+			values = append(values, v)
+		}
+	}
+	chef := chefModel(r.Difficulty, r.Generosity)
+	if r.Chef != nil {
+		chef = *r.Chef
+	}
+	s, err := NewSolver(values, len(r.Board), chef, u)
+	if err != nil {
+		return nil, err
+	}
+
+	decisions := []Decision{}
+	for _, o := range r.Offers {
+		if o.Kind != CashOffer || (o.Response != ResponseDeal && o.Response != ResponseNoDeal) {
+			continue
+		}
+		opened := openedAt(o.Opened)
+		var mask uint32
+		for bit, idx := range trays {
+			if !opened[idx] {
+				mask |= 1 << bit
+			}
+		}
+		playOn := s.PlayOn(mask)
+		d := Decision{
+			Round:    o.Round,
+			Offer:    o.Amount,
+			PlayOn:   int(math.Round(u.Inverse(playOn))),
+			Took:     o.Response == ResponseDeal,
+			BestTake: u.U(float64(o.Amount)) >= playOn,
+		}
+		if d.Took != d.BestTake {
+			d.Cost = abs(d.PlayOn - d.Offer)
+		}
+		decisions = append(decisions, d)
+	}
+	return decisions, nil
+}
@@ -0,0 +1,150 @@
+package main
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"fyne.io/fyne/v2/test"
+)
+
+// bruteBest is the solver's recursion written plainly over value lists,
+// to check the bitmask bookkeeping against
+func bruteBest(values []int, total int, c ChefModel, u Utility) (best, on float64) {
+	left := len(values)
+	if left == 2 {
+		on = (u.U(float64(values[0])) + u.U(float64(values[1]))) / 2
+	} else {
+		for i := range values {
+			rest := append(append([]int{}, values[:i]...), values[i+1:]...)
+			b, _ := bruteBest(rest, total, c, u)
+			on += b
+		}
+		on /= float64(left)
+	}
+	opened := total - left
+	if opened == 0 || (opened%3 != 0 && left != 2) {
+		return on, on
+	}
+	sum, count := 0, 0
+	for _, v := range values {
+		if v > 0 {
+			sum += v
+			count++
+		}
+	}
+	cash := math.Max(u.U(0), on)
+	if count > 0 {
+		cash = 0
+		for i := 0; i < factorSamples; i++ {
+			f := c.MinFactor + (c.MaxFactor-c.MinFactor)*(float64(i)+0.5)/factorSamples
+			cash += math.Max(u.U(math.Max(1, math.Floor(float64(sum)/float64(count)*f))), on)
+		}
+		cash /= factorSamples
+	}
+	return c.SwapChance*on + (1-c.SwapChance)*cash, on
+}
+
+func full(n int) uint32 { return 1<<n - 1 }
+
+func TestSolverMatchesBruteForce(t *testing.T) {
+	values := []int{1, 0, 100, 750, 5000, 0, 50000, 1000000}
+	for _, u := range []Utility{ExpectedValue, LogUtility} {
+		for _, total := range []int{8, 9, 11} {
+			s, err := NewSolver(values, total, chefModel("normal", 1), u)
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, want := bruteBest(values, total, s.chef, u)
+			if got := s.PlayOn(full(len(values))); math.Abs(got-want) > 1e-4*math.Abs(want) {
+				t.Errorf("%s, %d trays: PlayOn = %v, want %v", u.Name, total, got, want)
+			}
+		}
+	}
+}
+
+func TestSolverWithoutCashOffersIsTheAverage(t *testing.T) {
+	// a Chef who only ever offers swaps cannot change the expected value
+	values := []int{1, 10, 100, 1000, 10000, 100000, 1000000}
+	s, err := NewSolver(values, 10, ChefModel{0.5, 0.9, 1, 3}, ExpectedValue)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := s.PlayOn(full(len(values))); math.Abs(got-158730.14) > 0.1 {
+		t.Errorf("PlayOn = %v, want the average 158730.14", got)
+	}
+}
+
+func TestSolverThreshold(t *testing.T) {
+	values := []int{5, 200, 10000, 400000, 1000000}
+	ev, err := NewSolver(values, 26, chefModel("normal", 1), ExpectedValue)
+	if err != nil {
+		t.Fatal(err)
+	}
+	careful, _ := NewSolver(values, 26, chefModel("normal", 1), LogUtility)
+
+	// with two trays left turning the offer down is a coin flip
+	if got := ev.Threshold(0b10001); got != 500003 {
+		t.Errorf("Threshold with $5 and $1,000,000 = %d, want 500003", got)
+	}
+	all := full(len(values))
+	if ev.Threshold(all) < 282041 || ev.Threshold(all) > 1000000 {
+		t.Errorf("Threshold = %d, want at least the average 282041", ev.Threshold(all))
+	}
+	// a player who would rather have a sure thing settles for less
+	if careful.Threshold(all) >= ev.Threshold(all) {
+		t.Errorf("log utility threshold %d not below the EV one %d", careful.Threshold(all), ev.Threshold(all))
+	}
+}
+
+func TestSolverLimits(t *testing.T) {
+	if _, err := NewSolver(make([]int, maxSolverTrays+1), 26, ChefModel{}, ExpectedValue); err == nil {
+		t.Error("a board over the limit was solved")
+	}
+	if _, err := NewSolver([]int{1}, 26, ChefModel{}, ExpectedValue); err == nil {
+		t.Error("a single tray was solved")
+	}
+}
+
+func TestAnalyseGame(t *testing.T) {
+	test.NewTempApp(t)
+	r := sampleResult()
+	decisions, err := AnalyseGame(r, ExpectedValue)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the swap offer is not a decision that matters
+	if len(decisions) != 1 {
+		t.Fatalf("decisions = %+v, want the one cash offer", decisions)
+	}
+	d := decisions[0]
+	if d.Took || d.BestTake || d.Cost != 0 || d.PlayOn < 341700 {
+		t.Errorf("turning $160,000 down with $100, $25,000 and $1,000,000 left: %+v", d)
+	}
+
+	r.Offers[0].Response = ResponseDeal
+	decisions, _ = AnalyseGame(r, ExpectedValue)
+	if d := decisions[0]; !d.Took || d.Cost != d.PlayOn-160000 {
+		t.Errorf("taking the $160,000 deal: %+v", d)
+	}
+
+	r.Offers[1].By = offerByHost
+	if _, err := AnalyseGame(r, ExpectedValue); !errors.As(err, &OffersByPerson{}) {
+		t.Errorf("a game with a host's offer gave %v, want OffersByPerson", err)
+	}
+	r.Offers[1].By = ""
+
+	r.Board = nil
+	if decisions, err := AnalyseGame(r, ExpectedValue); decisions != nil || err != nil {
+		t.Errorf("a result without detail gave %+v, %v", decisions, err)
+	}
+}
+
+func BenchmarkSolverFullBoard(b *testing.B) {
+	values := boardValues(NUM_TRAYS)[3:] // left at the first offer
+	for i := 0; i < b.N; i++ {
+		if _, err := NewSolver(values, NUM_TRAYS, chefModel("normal", 1), ExpectedValue); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package main
+
+import "fyne.io/fyne/v2"
+
+// Stats are the lifetime results kept in the app preferences
+type Stats struct {
+	GamesPlayed   int
+	DealsTaken    int
+	TotalWinnings int
+	BestWin       int
+	FinalKeeps    int // kept own tray at the end
+	FinalKeepWins int // ...and it was the better tray
+	FinalSwaps    int // swapped with the last tray at the end
+	FinalSwapWins int // ...and it was the better tray
+}
+
+func LoadStats(p fyne.Preferences) *Stats {
+	return &Stats{
+		GamesPlayed:   p.Int("stats.gamesPlayed"),
+		DealsTaken:    p.Int("stats.dealsTaken"),
+		TotalWinnings: p.Int("stats.totalWinnings"),
+		BestWin:       p.Int("stats.bestWin"),
+		FinalKeeps:    p.Int("stats.finalKeeps"),
+		FinalKeepWins: p.Int("stats.finalKeepWins"),
+		FinalSwaps:    p.Int("stats.finalSwaps"),
+		FinalSwapWins: p.Int("stats.finalSwapWins"),
+	}
+}
+
+func (s *Stats) Save(p fyne.Preferences) {
+	p.SetInt("stats.gamesPlayed", s.GamesPlayed)
+	p.SetInt("stats.dealsTaken", s.DealsTaken)
+	p.SetInt("stats.totalWinnings", s.TotalWinnings)
+	p.SetInt("stats.bestWin", s.BestWin)
+	p.SetInt("stats.finalKeeps", s.FinalKeeps)
+	p.SetInt("stats.finalKeepWins", s.FinalKeepWins)
+	p.SetInt("stats.finalSwaps", s.FinalSwaps)
+	p.SetInt("stats.finalSwapWins", s.FinalSwapWins)
+}
+
+func (s *Stats) addWinnings(amount int) {
+	s.GamesPlayed++
+	s.TotalWinnings += amount
+	if amount > s.BestWin {
+		s.BestWin = amount
+	}
+}
+
+// RecordDeal stores a game that ended with an accepted Chef offer
+func (s *Stats) RecordDeal(offer int) {
+	s.DealsTaken++
+	s.addWinnings(offer)
+}
+
+// RecordFinal stores a game that went to the end. won is what the player
+// took home and other what was in the tray they left behind (food = 0).
+func (s *Stats) RecordFinal(swapped bool, won, other int) {
+	if swapped {
+		s.FinalSwaps++
+		if won > other {
+			s.FinalSwapWins++
+		}
+	} else {
+		s.FinalKeeps++
+		if won > other {
+			s.FinalKeepWins++
+		}
+	}
+	s.addWinnings(won)
+}
+
+// Summary is the short text shown on the Game Over dialog
+func (s *Stats) Summary() string {
+	return T("stats.summary",
+		s.GamesPlayed, s.DealsTaken, Money(s.TotalWinnings), Money(s.BestWin),
+		s.FinalKeepWins, s.FinalKeeps, s.FinalSwapWins, s.FinalSwaps)
+}
+
+// Load, change and save the stats in one go
+func updateStats(update func(s *Stats)) *Stats {
+	p := fyne.CurrentApp().Preferences()
+	s := LoadStats(p)
+	update(s)
+	s.Save(p)
+	return s
+}
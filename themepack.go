@@ -0,0 +1,232 @@
+package main
+
+import (
+	"archive/zip"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"image/color"
+	"io"
+	"io/fs"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/theme"
+)
+
+//go:embed themes/food/pack.json
+var embeddedThemes embed.FS
+
+// PackItem is one item that can replace a value on the board
+type PackItem struct {
+	Name  string `json:"name"`
+	Asset string `json:"asset"`
+}
+
+// ThemePack describes the look of the game: labels, colours, the items
+// that can hide in trays and the banker's pictures. Packs are a directory
+// or zip file with a pack.json; any "assets" they list are read from there.
+type ThemePack struct {
+	Name      string            `json:"name"`
+	Title     string            `json:"title"`
+	TrayIcon  string            `json:"trayIcon"`
+	ItemLabel string            `json:"itemLabel"`
+	Palette   map[string]string `json:"palette"` // fyne colour name -> #RRGGBB[AA]
+	Items     []PackItem        `json:"items"`
+	BankerArt []string          `json:"bankerArt"` // asset names
+	Assets    map[string]string `json:"assets"`    // asset name -> file in the pack
+
+	Source string `json:"-"` // path the pack came from, "" for the built-in one
+	fsys   fs.FS
+	closer io.Closer // the zip file, nil unless the pack is one
+}
+
+// currentPack is the theme used for new boards and dialogs
+var currentPack = defaultThemePack()
+
+func defaultThemePack() *ThemePack {
+	fsys, err := fs.Sub(embeddedThemes, "themes/food")
+	if err != nil {
+		panic(err)
+	}
+	p, err := readThemePack(fsys, nil)
+	if err != nil {
+		panic(err)
+	}
+	return p
+}
+
+// LoadThemePack opens a pack directory or zip file
+func LoadThemePack(path string) (*ThemePack, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var fsys fs.FS
+	var closer io.Closer
+	if info.IsDir() {
+		fsys = os.DirFS(path)
+	} else {
+		// the reader stays open for as long as the pack is in use
+		r, err := zip.OpenReader(path)
+		if err != nil {
+			return nil, fmt.Errorf("theme pack %s: %w", path, err)
+		}
+		fsys, closer = r, r
+	}
+
+	p, err := readThemePack(fsys, defaultThemePack())
+	if err != nil {
+		if closer != nil {
+			closer.Close()
+		}
+		return nil, fmt.Errorf("theme pack %s: %w", path, err)
+	}
+	p.Source = path
+	p.closer = closer
+	return p, nil
+}
+
+// Close lets go of the zip file of a pack no longer in use
+func (p *ThemePack) Close() error {
+	if p.closer == nil {
+		return nil
+	}
+	return p.closer.Close()
+}
+
+// readThemePack decodes pack.json; fields it leaves out come from fallback
+func readThemePack(fsys fs.FS, fallback *ThemePack) (*ThemePack, error) {
+	data, err := fs.ReadFile(fsys, "pack.json")
+	if err != nil {
+		return nil, err
+	}
+	p := &ThemePack{}
+	if err := json.Unmarshal(data, p); err != nil {
+		return nil, err
+	}
+	p.fsys = fsys
+
+	if p.Name == "" {
+		return nil, fmt.Errorf("pack.json has no name")
+	}
+	for key, hex := range p.Palette {
+		if _, err := parseHexColor(hex); err != nil {
+			return nil, fmt.Errorf("palette %s: %w", key, err)
+		}
+	}
+	if fallback != nil {
+		if p.Title == "" {
+			p.Title = fallback.Title
+		}
+		if p.TrayIcon == "" {
+			p.TrayIcon = fallback.TrayIcon
+		}
+		if p.ItemLabel == "" {
+			p.ItemLabel = fallback.ItemLabel
+		}
+		if len(p.Items) == 0 {
+			p.Items = fallback.Items
+		}
+		if len(p.BankerArt) == 0 {
+			p.BankerArt = fallback.BankerArt
+		}
+	}
+	return p, nil
+}
+
+// findThemePacks lists pack directories and zip files inside dir
+func findThemePacks(dir string) []string {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+	found := []string{}
+	for _, e := range entries {
+		path := filepath.Join(dir, e.Name())
+		if e.IsDir() {
+			if _, err := os.Stat(filepath.Join(path, "pack.json")); err == nil {
+				found = append(found, path)
+			}
+		} else if strings.EqualFold(filepath.Ext(e.Name()), ".zip") {
+			found = append(found, path)
+		}
+	}
+	sort.Strings(found)
+	return found
+}
+
+// TrayLabel is the text on tray number n (1-based)
+func (p *ThemePack) TrayLabel(n int) string {
+	return fmt.Sprintf("%s %d", p.TrayIcon, n)
+}
+
+// RandomItem picks an item for a tray
+func (p *ThemePack) RandomItem(r *rand.Rand) PackItem {
+	return p.Items[r.Intn(len(p.Items))]
+}
+
+// useThemePack makes p the current pack: its art goes on top of the
+// base images and its palette becomes the app theme
+func useThemePack(p *ThemePack) {
+	currentPack = p
+	assets = baseAssets
+	if len(p.Assets) > 0 {
+		assets = baseAssets.WithLayer(p.fsys, p.Assets)
+	}
+	fyne.CurrentApp().Settings().SetTheme(newPackTheme(p))
+}
+
+// packTheme is the default Fyne theme with the pack's palette on top
+type packTheme struct {
+	fyne.Theme
+	colors map[fyne.ThemeColorName]color.Color
+}
+
+func newPackTheme(p *ThemePack) fyne.Theme {
+	t := &packTheme{Theme: theme.DefaultTheme(), colors: map[fyne.ThemeColorName]color.Color{}}
+	for key, hex := range p.Palette {
+		c, _ := parseHexColor(hex) // checked when the pack was read
+		t.colors[fyne.ThemeColorName(key)] = c
+	}
+	return t
+}
+
+func (t *packTheme) Color(name fyne.ThemeColorName, variant fyne.ThemeVariant) color.Color {
+	if c, ok := t.colors[name]; ok {
+		return c
+	}
+	return t.Theme.Color(name, variant)
+}
+
+// Size draws everything bigger in TV mode and for fingers in the mobile profile
+func (t *packTheme) Size(name fyne.ThemeSizeName) float32 {
+	size := t.Theme.Size(name)
+	if tvMode {
+		size *= tvZoom
+	}
+	if mobileUI {
+		size *= touchZoom
+	}
+	return size
+}
+
+// parseHexColor reads #RRGGBB or #RRGGBBAA
+func parseHexColor(s string) (color.Color, error) {
+	c := color.NRGBA{A: 255}
+	var err error
+	switch len(s) {
+	case 7:
+		_, err = fmt.Sscanf(s, "#%02x%02x%02x", &c.R, &c.G, &c.B)
+	case 9:
+		_, err = fmt.Sscanf(s, "#%02x%02x%02x%02x", &c.R, &c.G, &c.B, &c.A)
+	default:
+		err = fmt.Errorf("%q is not #RRGGBB or #RRGGBBAA", s)
+	}
+	return c, err
+}
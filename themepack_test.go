@@ -0,0 +1,52 @@
+package main
+
+import (
+	"archive/zip"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"fyne.io/fyne/v2/test"
+)
+
+// writeZipPack writes a zip theme pack with just a name
+func writeZipPack(t *testing.T, name string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name+".zip")
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	z := zip.NewWriter(f)
+	w, _ := z.Create("pack.json")
+	w.Write([]byte(`{"name": "` + name + `"}`))
+	if err := z.Close(); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+	return path
+}
+
+func TestSwitchThemePackClosesZip(t *testing.T) {
+	test.NewTempApp(t)
+	defer useThemePack(defaultThemePack())
+
+	if err := switchThemePack(writeZipPack(t, "picnic")); err != nil {
+		t.Fatal(err)
+	}
+	first := currentPack
+	if err := switchThemePack(writeZipPack(t, "bakery")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := fs.ReadFile(first.fsys, "pack.json"); err == nil {
+		t.Error("the zip of the pack switched away from is still open")
+	}
+	second := currentPack
+	if err := switchThemePack(""); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := fs.ReadFile(second.fsys, "pack.json"); err == nil {
+		t.Error("the zip is still open after going back to the built-in pack")
+	}
+}
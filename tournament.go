@@ -0,0 +1,338 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+// Ways to rank the players of a tournament
+const (
+	ScoreTotal = "total" // most winnings over all games
+	ScoreRatio = "ratio" // best deal compared to what was in the tray
+)
+
+// Tournament is a round-robin where every player plays the same N boards.
+// It is kept in the preferences so it can go on over several days.
+type Tournament struct {
+	Created string            `json:"created"`
+	Players []string          `json:"players"`
+	Seeds   []int64           `json:"seeds"` // one board per game, the same for everyone
+	Scoring string            `json:"scoring"`
+	Config  GameConfig        `json:"config"` // setup every game is played with
+	Results []TournamentEntry `json:"results"`
+}
+
+// TournamentEntry is one tournament game. It is recorded as forfeited when
+// the game starts and filled in when it ends, so a game that is left
+// counts as 0 instead of being played again.
+type TournamentEntry struct {
+	Player    string `json:"player"`
+	Game      int    `json:"game"` // 1-based
+	Winnings  int    `json:"winnings"`
+	TrayValue int    `json:"trayValue"`
+	Deal      bool   `json:"deal"`
+	Forfeit   bool   `json:"forfeit,omitempty"` // started but not finished
+}
+
+// Standing is a player's line in the standings table
+type Standing struct {
+	Player    string
+	Played    int
+	Total     int
+	BestRatio float64
+}
+
+// NewTournament deals a fresh set of boards for players
+func NewTournament(players []string, games int, scoring string, cfg GameConfig) *Tournament {
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	t := &Tournament{
+		Created: time.Now().Format(dailyLayout),
+		Players: players,
+		Scoring: scoring,
+		Config:  cfg,
+	}
+	for i := 0; i < games; i++ {
+		t.Seeds = append(t.Seeds, r.Int63()+1)
+	}
+	return t
+}
+
+func LoadTournament(p fyne.Preferences) *Tournament {
+	data := p.String("tournament.current")
+	if data == "" {
+		return nil
+	}
+	t := &Tournament{}
+	if err := json.Unmarshal([]byte(data), t); err != nil {
+		fyne.LogError("Could not read the tournament", err)
+		return nil
+	}
+	return t
+}
+
+func (t *Tournament) Save(p fyne.Preferences) {
+	if t == nil {
+		p.RemoveValue("tournament.current")
+		return
+	}
+	data, err := json.Marshal(t)
+	if err != nil {
+		fyne.LogError("Could not save the tournament", err)
+		return
+	}
+	p.SetString("tournament.current", string(data))
+}
+
+// NextGame is the first game the player has not started yet, 0 when done
+func (t *Tournament) NextGame(player string) int {
+	for game := 1; game <= len(t.Seeds); game++ {
+		played := false
+		for _, e := range t.Results {
+			if e.Player == player && e.Game == game {
+				played = true
+			}
+		}
+		if !played {
+			return game
+		}
+	}
+	return 0
+}
+
+// Finished is true once everyone played every game
+func (t *Tournament) Finished() bool {
+	for _, p := range t.Players {
+		if t.NextGame(p) != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// dealRatio compares what the player took home with what their tray held.
+// An empty or food tray counts as 1 so taking anything beats it.
+func dealRatio(winnings, trayValue int) float64 {
+	if trayValue < 1 {
+		trayValue = 1
+	}
+	return float64(winnings) / float64(trayValue)
+}
+
+// Standings ranks the players by the tournament's scoring rule
+func (t *Tournament) Standings() []Standing {
+	standings := []Standing{}
+	for _, p := range t.Players {
+		s := Standing{Player: p}
+		for _, e := range t.Results {
+			if e.Player != p {
+				continue
+			}
+			s.Played++
+			s.Total += e.Winnings
+			if r := dealRatio(e.Winnings, e.TrayValue); r > s.BestRatio {
+				s.BestRatio = r
+			}
+		}
+		standings = append(standings, s)
+	}
+	sort.SliceStable(standings, func(i, j int) bool {
+		if t.Scoring == ScoreRatio && standings[i].BestRatio != standings[j].BestRatio {
+			return standings[i].BestRatio > standings[j].BestRatio
+		}
+		return standings[i].Total > standings[j].Total
+	})
+	return standings
+}
+
+// recordTournament stores the outcome of a tournament game over the
+// forfeit recorded when it started
+func (g *Game) recordTournament(deal bool, winnings int) {
+	p := fyne.CurrentApp().Preferences()
+	t := LoadTournament(p)
+	if t == nil {
+		return
+	}
+	e := TournamentEntry{
+		Player:    g.config.PlayerName,
+		Game:      g.tournamentGame,
+		Winnings:  winnings,
+		TrayValue: g.trayWinnings(g.playerTray),
+		Deal:      deal,
+	}
+	for i, r := range t.Results {
+		if r.Player == e.Player && r.Game == e.Game {
+			if r.Forfeit {
+				t.Results[i] = e
+				t.Save(p)
+			}
+			return
+		}
+	}
+	t.Results = append(t.Results, e)
+	t.Save(p)
+}
+
+// startTournamentGame plays game number game of the tournament for player
+func startTournamentGame(w fyne.Window, t *Tournament, player string, game int) {
+	p := fyne.CurrentApp().Preferences()
+	clearSavedGame(p)
+	// the game counts as 0 until it ends, so looking at the board and
+	// leaving does not give the player another go at it
+	t.Results = append(t.Results, TournamentEntry{Player: player, Game: game, Forfeit: true})
+	t.Save(p)
+	cfg := t.Config
+	cfg.PlayerName = player
+	cfg.Seed = t.Seeds[game-1]
+	g := NewGame(cfg)
+	g.tournamentGame = game
+	g.win = w
+	g.initialize()
+	g.showBoard(fyne.CurrentApp(), nil)
+}
+
+// parsePlayers reads one player name per line
+func parsePlayers(text string) ([]string, error) {
+	players := []string{}
+	seen := map[string]bool{}
+	for _, line := range strings.Split(text, "\n") {
+		name := strings.TrimSpace(line)
+		if name == "" {
+			continue
+		}
+		if seen[name] {
+			return nil, errors.New(T("tournament.duplicate", name))
+		}
+		seen[name] = true
+		players = append(players, name)
+	}
+	if len(players) < 2 {
+		return nil, errors.New(T("tournament.too_few"))
+	}
+	return players, nil
+}
+
+// showTournament is the tournament screen: set one up, or see the
+// standings and whose turn it is
+func showTournament(w fyne.Window) {
+	leaveGame()
+	if t := LoadTournament(fyne.CurrentApp().Preferences()); t != nil {
+		showStandings(w, t)
+		return
+	}
+	showNewTournament(w)
+}
+
+func showNewTournament(w fyne.Window) {
+	prefs := fyne.CurrentApp().Preferences()
+
+	players := widget.NewMultiLineEntry()
+	players.SetPlaceHolder(T("tournament.players_placeholder"))
+	players.SetMinRowsVisible(5)
+
+	games := widget.NewSlider(1, 10)
+	games.Step = 1
+	games.Value = 3
+
+	scoringKeys := []string{ScoreTotal, ScoreRatio}
+	scoringNames := []string{T("tournament.scoring.total"), T("tournament.scoring.ratio")}
+	scoring := widget.NewSelect(scoringNames, nil)
+	scoring.SetSelected(scoringNames[0])
+
+	form := widget.NewForm(
+		widget.NewFormItem(T("tournament.players"), players),
+		widget.NewFormItem(T("tournament.games"), sliderRow(games, func(v float64) string { return fmt.Sprintf("%.0f", v) })),
+		widget.NewFormItem(T("tournament.scoring"), scoring),
+	)
+
+	create := widget.NewButton(T("tournament.create"), func() {
+		names, err := parsePlayers(players.Text)
+		if err != nil {
+			dialog.ShowError(err, w)
+			return
+		}
+		rule := ScoreTotal
+		for i, n := range scoringNames {
+			if n == scoring.Selected {
+				rule = scoringKeys[i]
+			}
+		}
+		// every game uses the start screen setup, but with the tournament's boards
+		t := NewTournament(names, int(games.Value), rule, LoadGameConfig(prefs))
+		t.Save(prefs)
+		showStandings(w, t)
+	})
+	create.Importance = widget.HighImportance
+	back := widget.NewButton(T("tournament.back"), func() { showLobby(w) })
+
+	box := container.NewVBox(
+		container.NewCenter(widget.NewLabel(T("tournament.intro"))),
+		form,
+		container.NewCenter(container.NewHBox(create, back)),
+	)
+	w.SetContent(container.NewCenter(container.NewGridWrap(fyne.NewSize(460, box.MinSize().Height), box)))
+}
+
+func showStandings(w fyne.Window, t *Tournament) {
+	prefs := fyne.CurrentApp().Preferences()
+
+	table := container.NewGridWithColumns(6,
+		widget.NewLabel("#"),
+		widget.NewLabel(T("tournament.player")),
+		widget.NewLabel(T("tournament.played")),
+		widget.NewLabel(T("tournament.total")),
+		widget.NewLabel(T("tournament.best_ratio")),
+		widget.NewLabel(""),
+	)
+	for i, s := range t.Standings() {
+		player := s.Player
+		next := widget.NewLabel(T("tournament.done"))
+		var play fyne.CanvasObject = next
+		if game := t.NextGame(player); game != 0 {
+			play = widget.NewButton(T("tournament.play", game), func() {
+				startTournamentGame(w, t, player, game)
+			})
+		}
+		table.Add(widget.NewLabel(fmt.Sprintf("%d", i+1)))
+		table.Add(widget.NewLabel(player))
+		table.Add(widget.NewLabel(fmt.Sprintf("%d/%d", s.Played, len(t.Seeds))))
+		table.Add(widget.NewLabel(Money(s.Total)))
+		table.Add(widget.NewLabel(fmt.Sprintf("%.2f×", s.BestRatio)))
+		table.Add(play)
+	}
+
+	rule := T("tournament.scoring." + t.Scoring)
+	status := T("tournament.status", t.Created, len(t.Seeds), rule)
+	if t.Finished() {
+		status = T("tournament.finished", t.Standings()[0].Player) + "\n" + status
+	}
+
+	end := widget.NewButton(T("tournament.end"), func() {
+		dialog.ShowConfirm(T("tournament.end"), T("tournament.end_confirm"), func(ok bool) {
+			if ok {
+				(*Tournament)(nil).Save(prefs)
+				showLobby(w)
+			}
+		}, w)
+	})
+	back := widget.NewButton(T("tournament.back"), func() { showLobby(w) })
+
+	w.SetContent(container.NewBorder(
+		container.NewVBox(
+			container.NewCenter(widget.NewLabelWithStyle(T("tournament.title"), fyne.TextAlignCenter, fyne.TextStyle{Bold: true})),
+			container.NewCenter(widget.NewLabel(status)),
+		),
+		container.NewCenter(container.NewHBox(back, end)),
+		nil, nil,
+		container.NewVScroll(container.NewPadded(table)),
+	))
+}
@@ -0,0 +1,124 @@
+package main
+
+import (
+	"testing"
+
+	"fyne.io/fyne/v2"
+)
+
+func TestDealRatio(t *testing.T) {
+	tests := []struct {
+		winnings, tray int
+		want           float64
+	}{
+		{50000, 100000, 0.5},
+		{200000, 100, 2000},
+		{100, 100, 1},
+		{300, 0, 300}, // food in the tray
+		{0, 1000000, 0},
+	}
+	for _, tt := range tests {
+		if got := dealRatio(tt.winnings, tt.tray); got != tt.want {
+			t.Errorf("dealRatio(%d, %d) = %v, want %v", tt.winnings, tt.tray, got, tt.want)
+		}
+	}
+}
+
+// sampleTournament has Ana with the most winnings and Bo with the best deal
+func sampleTournament(scoring string) *Tournament {
+	return &Tournament{
+		Players: []string{"Ana", "Bo", "Cy"},
+		Seeds:   []int64{1, 2, 3},
+		Scoring: scoring,
+		Results: []TournamentEntry{
+			{Player: "Ana", Game: 1, Winnings: 300000, TrayValue: 400000, Deal: true},
+			{Player: "Bo", Game: 1, Winnings: 5000, TrayValue: 10, Deal: true},
+			{Player: "Ana", Game: 2, Winnings: 100000, TrayValue: 100000},
+			{Player: "Bo", Game: 2, Forfeit: true},
+		},
+	}
+}
+
+func TestStandings(t *testing.T) {
+	tests := []struct {
+		scoring string
+		order   []string
+	}{
+		{ScoreTotal, []string{"Ana", "Bo", "Cy"}},
+		{ScoreRatio, []string{"Bo", "Ana", "Cy"}},
+	}
+	for _, tt := range tests {
+		standings := sampleTournament(tt.scoring).Standings()
+		for i, s := range standings {
+			if s.Player != tt.order[i] {
+				t.Errorf("%s: place %d is %s, want %s", tt.scoring, i+1, s.Player, tt.order[i])
+			}
+		}
+		for _, s := range standings {
+			var want Standing
+			switch s.Player {
+			case "Ana":
+				want = Standing{"Ana", 2, 400000, 1}
+			case "Bo":
+				want = Standing{"Bo", 2, 5000, 500}
+			case "Cy":
+				want = Standing{Player: "Cy"}
+			}
+			if s != want {
+				t.Errorf("%s: %+v, want %+v", tt.scoring, s, want)
+			}
+		}
+	}
+}
+
+func TestNextGame(t *testing.T) {
+	tour := sampleTournament(ScoreTotal)
+	for player, want := range map[string]int{"Ana": 3, "Bo": 3, "Cy": 1, "Dee": 1} {
+		if got := tour.NextGame(player); got != want {
+			t.Errorf("NextGame(%s) = %d, want %d", player, got, want)
+		}
+	}
+	if tour.Finished() {
+		t.Error("finished with games left")
+	}
+	// games are not played in order if one was skipped
+	tour.Results = append(tour.Results, TournamentEntry{Player: "Cy", Game: 2})
+	if got := tour.NextGame("Cy"); got != 1 {
+		t.Errorf("NextGame(Cy) = %d, want the skipped game 1", got)
+	}
+}
+
+func TestLeftTournamentGameIsForfeited(t *testing.T) {
+	_, w := newTestGame(t, testConfig())
+	p := fyne.CurrentApp().Preferences()
+	NewTournament([]string{"Ana", "Bo"}, 2, ScoreTotal, testConfig()).Save(p)
+
+	// Ana looks at the board of game 1 and goes back to the standings
+	startTournamentGame(w, LoadTournament(p), "Ana", 1)
+	showTournament(w)
+	tour := LoadTournament(p)
+	if got := tour.NextGame("Ana"); got != 2 {
+		t.Fatalf("NextGame after leaving game 1 = %d, want 2", got)
+	}
+	if s := tour.Standings(); s[0].Player != "Ana" || s[0].Played != 1 || s[0].Total != 0 {
+		t.Errorf("standings = %+v, want Ana with a game played for 0", s)
+	}
+
+	// game 2 played to a deal replaces its forfeit
+	startTournamentGame(w, tour, "Ana", 2)
+	g := activeGame
+	g.chef.swapChance = 0
+	pickTray(t, g, w, 0)
+	openTrays(t, g, w, 3)
+	tapDialog(t, w, T("accept"))
+	tour = LoadTournament(p)
+	if len(tour.Results) != 2 {
+		t.Fatalf("results = %+v, want 2", tour.Results)
+	}
+	if e := tour.Results[1]; e.Game != 2 || e.Forfeit || !e.Deal || e.Winnings != g.offers[0].Amount {
+		t.Errorf("game 2 recorded as %+v", e)
+	}
+	if tour.NextGame("Ana") != 0 {
+		t.Error("Ana still has games to play")
+	}
+}